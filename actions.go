@@ -0,0 +1,86 @@
+package godo
+
+import (
+	"context"
+	"errors"
+)
+
+// actionAndWait invokes an action on a droplet and waits for its event. If the event fails, the EventError names the action.
+func (c *Client) actionAndWait(ctx context.Context, action string, fn func(c *Client) (int, error)) error {
+	err := c.waitForAction(ctx, fn)
+
+	var eventErr *EventError
+	if errors.As(err, &eventErr) {
+		// The error may be shared by the waiters of the event, so it is copied
+		named := *eventErr
+		named.Action = action
+		return &named
+	}
+
+	return err
+}
+
+// RebootDropletAndWait reboots a droplet like RebootDroplet and waits for the event to finish. A failed event is returned as an EventError. The wait is bounded by the client's operation timeout.
+func (c *Client) RebootDropletAndWait(ctx context.Context, ID int) error {
+	return c.actionAndWait(ctx, "reboot", func(c *Client) (int, error) { return c.RebootDroplet(ID) })
+}
+
+// PowerCycleDropletAndWait power cycles a droplet like PowerCycleDroplet and waits for the event to finish. A failed event is returned as an EventError. The wait is bounded by the client's operation timeout.
+func (c *Client) PowerCycleDropletAndWait(ctx context.Context, ID int) error {
+	return c.actionAndWait(ctx, "power cycle", func(c *Client) (int, error) { return c.PowerCycleDroplet(ID) })
+}
+
+// ShutDownDropletAndWait shuts a droplet down like ShutDownDroplet and waits for the event to finish. A failed event is returned as an EventError. The wait is bounded by the client's operation timeout.
+func (c *Client) ShutDownDropletAndWait(ctx context.Context, ID int) error {
+	return c.actionAndWait(ctx, "shutdown", func(c *Client) (int, error) { return c.ShutDownDroplet(ID) })
+}
+
+// PowerOffDropletAndWait powers a droplet off like PowerOffDroplet and waits for the event to finish. A failed event is returned as an EventError. The wait is bounded by the client's operation timeout.
+func (c *Client) PowerOffDropletAndWait(ctx context.Context, ID int) error {
+	return c.actionAndWait(ctx, "power off", func(c *Client) (int, error) { return c.PowerOffDroplet(ID) })
+}
+
+// PowerOnDropletAndWait powers a droplet on like PowerOnDroplet and waits for the event to finish. A failed event is returned as an EventError. The wait is bounded by the client's operation timeout.
+func (c *Client) PowerOnDropletAndWait(ctx context.Context, ID int) error {
+	return c.actionAndWait(ctx, "power on", func(c *Client) (int, error) { return c.PowerOnDroplet(ID) })
+}
+
+// ResetRootPassDropletAndWait resets the root password of a droplet like ResetRootPassDroplet and waits for the event to finish. A failed event is returned as an EventError. The wait is bounded by the client's operation timeout.
+func (c *Client) ResetRootPassDropletAndWait(ctx context.Context, ID int) error {
+	return c.actionAndWait(ctx, "password reset", func(c *Client) (int, error) { return c.ResetRootPassDroplet(ID) })
+}
+
+// ResizeDropletAndWait resizes a droplet like ResizeDropletWithOptions and waits for the event to finish. A failed event is returned as an EventError. The wait is bounded by the client's operation timeout.
+func (c *Client) ResizeDropletAndWait(ctx context.Context, ID int, size interface{}, opts ResizeOptions) error {
+	return c.actionAndWait(ctx, "resize", func(c *Client) (int, error) { return c.ResizeDropletWithOptions(ID, size, opts) })
+}
+
+// TakeSnapshotOnDropletAndWait takes a snapshot of a droplet like TakeSnapshotOnDroplet and waits for the event to finish. A failed event is returned as an EventError. The wait is bounded by the client's operation timeout.
+func (c *Client) TakeSnapshotOnDropletAndWait(ctx context.Context, ID int, name string) error {
+	return c.actionAndWait(ctx, "snapshot", func(c *Client) (int, error) { return c.TakeSnapshotOnDroplet(ID, name) })
+}
+
+// RestoreDropletAndWait restores a droplet from an image like RestoreDroplet and waits for the event to finish. A failed event is returned as an EventError. The wait is bounded by the client's operation timeout.
+func (c *Client) RestoreDropletAndWait(ctx context.Context, ID int, imageID int) error {
+	return c.actionAndWait(ctx, "restore", func(c *Client) (int, error) { return c.RestoreDroplet(ID, imageID) })
+}
+
+// RebuildDropletAndWait rebuilds a droplet from an image like RebuildDroplet and waits for the event to finish. A failed event is returned as an EventError. The wait is bounded by the client's operation timeout.
+func (c *Client) RebuildDropletAndWait(ctx context.Context, ID int, imageID int) error {
+	return c.actionAndWait(ctx, "rebuild", func(c *Client) (int, error) { return c.RebuildDroplet(ID, imageID) })
+}
+
+// RenameDropletAndWait renames a droplet like RenameDroplet and waits for the event to finish. A failed event is returned as an EventError. The wait is bounded by the client's operation timeout.
+func (c *Client) RenameDropletAndWait(ctx context.Context, ID int, name string) error {
+	return c.actionAndWait(ctx, "rename", func(c *Client) (int, error) { return c.RenameDroplet(ID, name) })
+}
+
+// EnableBackupsOnDropletAndWait enables automatic backups of a droplet like EnableBackupsOnDroplet and waits for the event to finish. A failed event is returned as an EventError. The wait is bounded by the client's operation timeout.
+func (c *Client) EnableBackupsOnDropletAndWait(ctx context.Context, ID int) error {
+	return c.actionAndWait(ctx, "enabling backups", func(c *Client) (int, error) { return c.EnableBackupsOnDroplet(ID) })
+}
+
+// DisableBackupsOnDropletAndWait disables automatic backups of a droplet like DisableBackupsOnDroplet and waits for the event to finish. A failed event is returned as an EventError. The wait is bounded by the client's operation timeout.
+func (c *Client) DisableBackupsOnDropletAndWait(ctx context.Context, ID int) error {
+	return c.actionAndWait(ctx, "disabling backups", func(c *Client) (int, error) { return c.DisableBackupsOnDroplet(ID) })
+}
@@ -0,0 +1,160 @@
+package godo
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// AnomalyKind is the kind of droplet change an AnomalyDetector counts
+type AnomalyKind string
+
+const (
+	// AnomalyDestroy counts droplets which disappear
+	AnomalyDestroy AnomalyKind = "destroy"
+	// AnomalyRebuild counts droplets whose image changes
+	AnomalyRebuild AnomalyKind = "rebuild"
+)
+
+const (
+	// DefaultAnomalyWindow is the period over which an AnomalyDetector counts changes
+	DefaultAnomalyWindow = 10 * time.Minute
+	// DefaultAnomalyThreshold is how many times the usual count of a window is anomalous
+	DefaultAnomalyThreshold = 3
+	// DefaultAnomalyMinCount is the count below which a window is never anomalous
+	DefaultAnomalyMinCount = 3
+
+	// anomalySmoothing is the weight of the latest window in the learned baseline
+	anomalySmoothing = 0.2
+)
+
+// Anomaly is a burst of changes of one kind, far above the usual rate
+type Anomaly struct {
+	Kind AnomalyKind
+	// Count is the number of changes in the current window
+	Count int
+	// Baseline is the learned number of changes per window
+	Baseline float64
+	Window   time.Duration
+	// Droplets are the droplets changed in the current window
+	Droplets []Droplet
+	At       time.Time
+}
+
+// AnomalyDetector learns the usual rate of destroyed and rebuilt droplets from the changes a DropletWatcher observes and reports bursts, e.g. a mass deletion with leaked credentials. Password resets are not counted since the API doesn't expose them in the droplet list.
+type AnomalyDetector struct {
+	// Window is the period over which changes are counted
+	Window time.Duration
+	// Threshold is how many times the baseline a window must exceed to be anomalous
+	Threshold float64
+	// MinCount is the count below which a window is never anomalous
+	MinCount int
+
+	notify  func(Anomaly)
+	mu      sync.Mutex
+	windows map[AnomalyKind]*anomalyWindow
+}
+
+type anomalyWindow struct {
+	start    time.Time
+	count    int
+	droplets []Droplet
+	baseline float64
+	alerted  bool
+}
+
+// NewAnomalyDetector returns a detector with the default settings which calls notify once per window in which a kind of change is anomalous
+func NewAnomalyDetector(notify func(Anomaly)) *AnomalyDetector {
+	return &AnomalyDetector{
+		Window:    DefaultAnomalyWindow,
+		Threshold: DefaultAnomalyThreshold,
+		MinCount:  DefaultAnomalyMinCount,
+		notify:    notify,
+		windows:   make(map[AnomalyKind]*anomalyWindow),
+	}
+}
+
+// Run observes the changes of a watcher until ctx is done or the watcher stops
+func (a *AnomalyDetector) Run(ctx context.Context, w *DropletWatcher) error {
+	sub := w.Subscribe(64, Block)
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e, ok := <-sub.C:
+			if !ok {
+				return nil
+			}
+			a.Observe(e)
+		}
+	}
+}
+
+// Observe counts a change and calls the notify function if it makes the current window anomalous
+func (a *AnomalyDetector) Observe(e DropletEvent) {
+	var kind AnomalyKind
+	switch {
+	case e.Type == DropletRemoved:
+		kind = AnomalyDestroy
+	case e.Type == DropletUpdated && e.Previous.ImageID != e.Droplet.ImageID:
+		kind = AnomalyRebuild
+	default:
+		return
+	}
+
+	if anomaly, ok := a.count(kind, e.Droplet, time.Now()); ok && a.notify != nil {
+		a.notify(anomaly)
+	}
+}
+
+func (a *AnomalyDetector) count(kind AnomalyKind, d Droplet, now time.Time) (Anomaly, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	w, ok := a.windows[kind]
+	if !ok {
+		w = &anomalyWindow{start: now}
+		a.windows[kind] = w
+	}
+	window := a.Window
+	if window <= 0 {
+		window = DefaultAnomalyWindow
+	}
+	w.roll(now, window)
+
+	w.count++
+	w.droplets = append(w.droplets, d)
+
+	if w.alerted || w.count < a.MinCount || float64(w.count) <= a.Threshold*max(w.baseline, 1) {
+		return Anomaly{}, false
+	}
+	w.alerted = true
+
+	return Anomaly{
+		Kind:     kind,
+		Count:    w.count,
+		Baseline: w.baseline,
+		Window:   window,
+		Droplets: append([]Droplet(nil), w.droplets...),
+		At:       now,
+	}, true
+}
+
+// roll starts a new window if the current one is over, folding its count into the baseline. Windows without any change count as zero.
+func (w *anomalyWindow) roll(now time.Time, window time.Duration) {
+	elapsed := int(now.Sub(w.start) / window)
+	if elapsed < 1 {
+		return
+	}
+
+	w.baseline += anomalySmoothing * (float64(w.count) - w.baseline)
+	w.baseline *= math.Pow(1-anomalySmoothing, float64(elapsed-1))
+
+	w.start = w.start.Add(time.Duration(elapsed) * window)
+	w.count = 0
+	w.droplets = nil
+	w.alerted = false
+}
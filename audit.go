@@ -0,0 +1,58 @@
+package godo
+
+import "context"
+
+// AuditReport lists the findings of a security posture audit of an account
+type AuditReport struct {
+	// DropletsWithoutBackups lists droplets which don't have automatic backups enabled
+	DropletsWithoutBackups []Droplet
+	// DomainsWithoutApex lists domains without an A or AAAA record for the apex
+	DomainsWithoutApex []Domain
+	// PublicImages lists the account's own images which are publicly available
+	PublicImages []Image
+}
+
+// Audit reports droplets without backups, domains without apex records and public images of the account. SSH keys are not audited since the API doesn't tell which keys droplets were created with.
+func (c *Client) Audit(ctx context.Context) (*AuditReport, error) {
+	inv, err := c.ExportInventory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return AuditInventory(inv), nil
+}
+
+// AuditInventory audits an inventory, see Audit
+func AuditInventory(inv *Inventory) *AuditReport {
+	report := &AuditReport{}
+
+	for _, d := range inv.Droplets {
+		if !d.BackupsActive {
+			report.DropletsWithoutBackups = append(report.DropletsWithoutBackups, d)
+		}
+	}
+
+	for _, d := range inv.Domains {
+		if !hasApexRecord(inv.Records[d.ID]) {
+			report.DomainsWithoutApex = append(report.DomainsWithoutApex, d)
+		}
+	}
+
+	for _, i := range inv.Images {
+		if i.Public {
+			report.PublicImages = append(report.PublicImages, i)
+		}
+	}
+
+	return report
+}
+
+func hasApexRecord(records []DomainRecord) bool {
+	for _, r := range records {
+		if r.Name == "@" && (r.RecordType == "A" || r.RecordType == "AAAA") {
+			return true
+		}
+	}
+
+	return false
+}
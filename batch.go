@@ -0,0 +1,131 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BatchFailure is an item of a batch operation which failed
+type BatchFailure[T any] struct {
+	Item T
+	Err  error
+}
+
+// BatchResult is the outcome of an operation on many resources, which continues after an item fails. Items keep the order in which they were passed.
+type BatchResult[T any] struct {
+	Succeeded []T
+	Failed    []BatchFailure[T]
+	// Duration is how long the whole batch took
+	Duration time.Duration
+}
+
+// Err returns the errors of the failed items joined, or nil if all items succeeded
+func (r *BatchResult[T]) Err() error {
+	errs := make([]error, len(r.Failed))
+	for i, f := range r.Failed {
+		errs[i] = f.Err
+	}
+
+	return errors.Join(errs...)
+}
+
+// runBatch runs fn for each item with the client's concurrency. fn returns the item as it is after the operation, e.g. with the ID of a created resource.
+func runBatch[T any](ctx context.Context, c *Client, items []T, fn func(ctx context.Context, item T) (T, error)) *BatchResult[T] {
+	start := time.Now()
+
+	type outcome struct {
+		item T
+		err  error
+	}
+
+	var (
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, c.concurrency())
+		outcomes = make([]outcome, len(items))
+	)
+
+	for i, item := range items {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				outcomes[i] = outcome{item, ctx.Err()}
+				return
+			}
+			defer func() { <-sem }()
+
+			out, err := fn(ctx, item)
+			if err != nil {
+				outcomes[i] = outcome{item, err}
+				return
+			}
+
+			outcomes[i] = outcome{out, nil}
+		}()
+	}
+
+	wg.Wait()
+
+	r := &BatchResult[T]{Duration: time.Since(start)}
+	for _, o := range outcomes {
+		if o.err != nil {
+			r.Failed = append(r.Failed, BatchFailure[T]{Item: o.item, Err: o.err})
+			continue
+		}
+
+		r.Succeeded = append(r.Succeeded, o.item)
+	}
+
+	return r
+}
+
+// DeleteDroplets destroys droplets, with the client's concurrency
+func (c *Client) DeleteDroplets(ctx context.Context, droplets []Droplet) *BatchResult[Droplet] {
+	return runBatch(ctx, c, droplets, func(ctx context.Context, d Droplet) (Droplet, error) {
+		if _, err := c.WithContext(ctx).DeleteDropletByID(d.ID); err != nil {
+			return d, fmt.Errorf("could not delete droplet %q: %w", d.Name, err)
+		}
+
+		return d, nil
+	})
+}
+
+// DropletCreation is a droplet created by CreateDroplets
+type DropletCreation struct {
+	Spec NewDroplet
+	// Droplet is nil if the creation failed
+	Droplet *PartialDroplet
+}
+
+// CreateDroplets creates n droplets from template, with the client's concurrency. The droplets are named by formatting namePattern with their number from 1 to n, e.g. "web-%02d" gives web-01 to web-05 for n of 5. Each failure is reported with the spec of the droplet.
+func (c *Client) CreateDroplets(ctx context.Context, n int, template NewDroplet, namePattern string) (*BatchResult[DropletCreation], error) {
+	if n < 1 {
+		return nil, fmt.Errorf("number of droplets must be at least 1")
+	}
+
+	if n > 1 && fmt.Sprintf(namePattern, 1) == fmt.Sprintf(namePattern, 2) {
+		return nil, fmt.Errorf("name pattern %q must contain the number of the droplet, e.g. %%d", namePattern)
+	}
+
+	items := make([]DropletCreation, n)
+	for i := range items {
+		items[i].Spec = template
+		items[i].Spec.Name = fmt.Sprintf(namePattern, i+1)
+	}
+
+	return runBatch(ctx, c, items, func(ctx context.Context, item DropletCreation) (DropletCreation, error) {
+		pd, err := c.WithContext(ctx).CreateDroplet(item.Spec)
+		if err != nil {
+			return item, fmt.Errorf("could not create droplet %q: %w", item.Spec.Name, err)
+		}
+
+		item.Droplet = pd
+		return item, nil
+	}), nil
+}
@@ -0,0 +1,236 @@
+package godo
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BootPhase is a milestone in provisioning a droplet, measured from its creation
+type BootPhase string
+
+const (
+	// BootActive is reached when the droplet is active and unlocked
+	BootActive BootPhase = "active"
+	// BootSSHReady is reached when the SSH server of the droplet answers
+	BootSSHReady BootPhase = "ssh_ready"
+)
+
+const (
+	// bootSamples is the number of durations kept per region, size and phase
+	bootSamples = 1000
+	// bootPendingTTL is how long a created droplet is tracked until it reaches all phases
+	bootPendingTTL = time.Hour
+)
+
+// ProvisioningObserver is implemented by a MetricsCollector which also wants the boot times of droplets, e.g. to alert on provisioning SLOs per region and size
+type ProvisioningObserver interface {
+	ObserveProvisioning(region, size string, phase BootPhase, d time.Duration)
+}
+
+// BootStats are the percentiles of the boot times of the droplets of a region and size
+type BootStats struct {
+	Region string
+	Size   string
+	Phase  BootPhase
+	Count  int
+	P50    time.Duration
+	P90    time.Duration
+	P99    time.Duration
+}
+
+type bootKey struct {
+	region, size string
+	phase        BootPhase
+}
+
+type pendingBoot struct {
+	region, size string
+	created      time.Time
+	reached      []BootPhase
+}
+
+// bootTracker measures the time from creating a droplet to each boot phase
+type bootTracker struct {
+	mu      sync.Mutex
+	pending map[int]pendingBoot
+	samples map[bootKey][]time.Duration
+}
+
+// WithBootTracking measures for each droplet created by the client how long it takes to become active, i.e. until WaitForDropletStatus returns it as active, and until its SSH server answers, i.e. until WaitForSSH returns. The percentiles are returned by BootStats and each duration is passed to the metrics collector if it implements ProvisioningObserver.
+func WithBootTracking() Option {
+	return func(c *Client) {
+		c.boots = &bootTracker{
+			pending: make(map[int]pendingBoot),
+			samples: make(map[bootKey][]time.Duration),
+		}
+	}
+}
+
+// bootLabel returns the slug of a size or region, or its ID if there is no slug
+func bootLabel(slug string, ID int) string {
+	if slug != "" {
+		return slug
+	}
+
+	return strconv.Itoa(ID)
+}
+
+// created starts tracking a droplet
+func (t *bootTracker) created(ID int, n NewDroplet) {
+	if t == nil || ID == 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	for id, p := range t.pending {
+		if now.Sub(p.created) > bootPendingTTL {
+			delete(t.pending, id)
+		}
+	}
+
+	t.pending[ID] = pendingBoot{
+		region:  bootLabel(n.RegionSlug, n.RegionID),
+		size:    bootLabel(n.SizeSlug, n.SizeID),
+		created: now,
+	}
+}
+
+// reached records the time a tracked droplet took to reach a phase. It returns false if the droplet isn't tracked or has reached the phase before.
+func (t *bootTracker) reached(ID int, phase BootPhase) (bootKey, time.Duration, bool) {
+	if t == nil {
+		return bootKey{}, 0, false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.pending[ID]
+	if !ok || slices.Contains(p.reached, phase) {
+		return bootKey{}, 0, false
+	}
+
+	if phase == BootSSHReady {
+		delete(t.pending, ID)
+	} else {
+		p.reached = append(p.reached, phase)
+		t.pending[ID] = p
+	}
+
+	key := bootKey{region: p.region, size: p.size, phase: phase}
+	d := time.Since(p.created)
+
+	samples := append(t.samples[key], d)
+	if len(samples) > bootSamples {
+		samples = samples[len(samples)-bootSamples:]
+	}
+	t.samples[key] = samples
+
+	return key, d, true
+}
+
+// bootReached records a boot phase of a droplet and passes it to the metrics collector
+func (c *Client) bootReached(ID int, phase BootPhase) {
+	key, d, ok := c.boots.reached(ID, phase)
+	if !ok {
+		return
+	}
+
+	if o, ok := c.Metrics.(ProvisioningObserver); ok {
+		o.ObserveProvisioning(key.region, key.size, phase, d)
+	}
+}
+
+// percentile returns the pth percentile of sorted durations
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	i := int(p*float64(len(sorted))+0.5) - 1
+	return sorted[max(0, min(i, len(sorted)-1))]
+}
+
+// BootStats returns the percentiles of the boot times measured with WithBootTracking, per region, size and phase
+func (c *Client) BootStats() []BootStats {
+	if c.boots == nil {
+		return nil
+	}
+
+	c.boots.mu.Lock()
+	defer c.boots.mu.Unlock()
+
+	stats := make([]BootStats, 0, len(c.boots.samples))
+	for key, samples := range c.boots.samples {
+		sorted := slices.Clone(samples)
+		slices.Sort(sorted)
+
+		stats = append(stats, BootStats{
+			Region: key.region,
+			Size:   key.size,
+			Phase:  key.phase,
+			Count:  len(sorted),
+			P50:    percentile(sorted, 0.5),
+			P90:    percentile(sorted, 0.9),
+			P99:    percentile(sorted, 0.99),
+		})
+	}
+
+	slices.SortFunc(stats, func(a, b BootStats) int {
+		return strings.Compare(a.Region+"/"+a.Size+"/"+string(a.Phase), b.Region+"/"+b.Size+"/"+string(b.Phase))
+	})
+
+	return stats
+}
+
+// WaitForSSH polls the SSH port of a droplet until its SSH server sends its banner, or until ctx is done. The wait is bounded by the client's operation timeout.
+func (c *Client) WaitForSSH(ctx context.Context, d *Droplet) error {
+	if c.DryRun() && d.ID == 0 {
+		// A droplet whose creation was planned
+		return nil
+	}
+
+	if d.IPAdress == "" {
+		return fmt.Errorf("droplet with ID %d has no IP address", d.ID)
+	}
+
+	ctx, cancel := c.operationContext(ctx)
+	defer cancel()
+
+	addr := net.JoinHostPort(d.IPAdress, "22")
+	for {
+		if sshBanner(ctx, addr) {
+			c.bootReached(d.ID, BootSSHReady)
+			return nil
+		}
+
+		if err := c.sleep(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// sshBanner reports whether an SSH server answers on addr
+func sshBanner(ctx context.Context, addr string) bool {
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return false
+	}
+	defer conn.Close()
+
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	return err == nil && strings.HasPrefix(line, "SSH-")
+}
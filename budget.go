@@ -0,0 +1,97 @@
+package godo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StepTiming records how long a step of a budgeted operation took
+type StepTiming struct {
+	Name     string
+	Duration time.Duration
+	Err      error
+}
+
+// BudgetExceededError is returned when an operation runs out of its budget. Step is the step which was running when the budget was spent.
+type BudgetExceededError struct {
+	Budget time.Duration
+	Step   string
+	Steps  []StepTiming
+}
+
+func (e *BudgetExceededError) Error() string {
+	timings := make([]string, len(e.Steps))
+	for i, s := range e.Steps {
+		timings[i] = fmt.Sprintf("%s: %v", s.Name, s.Duration.Round(time.Millisecond))
+	}
+
+	return fmt.Sprintf("operation budget of %v exceeded during step %q (%s)", e.Budget, e.Step, strings.Join(timings, ", "))
+}
+
+// Unwrap returns context.DeadlineExceeded so the error can be checked with errors.Is
+func (e *BudgetExceededError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// OperationBudget applies a total wall-clock budget across the steps of a composed workflow, e.g. create a droplet, wait for it and update DNS
+type OperationBudget struct {
+	mu       sync.Mutex
+	budget   time.Duration
+	deadline time.Time
+	steps    []StepTiming
+}
+
+// WithOperationBudget returns an OperationBudget of d. The clock starts running immediately.
+func WithOperationBudget(d time.Duration) *OperationBudget {
+	return &OperationBudget{
+		budget:   d,
+		deadline: time.Now().Add(d),
+	}
+}
+
+// Step runs fn with a context which expires when the remaining budget is spent. Returns a BudgetExceededError if the budget ran out before or during the step.
+func (b *OperationBudget) Step(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	if b.Remaining() <= 0 {
+		return b.exceeded(name)
+	}
+
+	stepCtx, cancel := context.WithDeadline(ctx, b.deadline)
+	defer cancel()
+
+	start := time.Now()
+	err := fn(stepCtx)
+
+	b.mu.Lock()
+	b.steps = append(b.steps, StepTiming{Name: name, Duration: time.Since(start), Err: err})
+	b.mu.Unlock()
+
+	if err != nil && ctx.Err() == nil && stepCtx.Err() == context.DeadlineExceeded {
+		return b.exceeded(name)
+	}
+
+	return err
+}
+
+// Remaining returns how much of the budget is left
+func (b *OperationBudget) Remaining() time.Duration {
+	return time.Until(b.deadline)
+}
+
+// Steps returns the timings of the steps which have run so far
+func (b *OperationBudget) Steps() []StepTiming {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return append([]StepTiming(nil), b.steps...)
+}
+
+func (b *OperationBudget) exceeded(step string) error {
+	return &BudgetExceededError{
+		Budget: b.budget,
+		Step:   step,
+		Steps:  b.Steps(),
+	}
+}
@@ -0,0 +1,81 @@
+package godo
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// buildExpiry is how long a build droplet may live before ReapExpired destroys it, in case cleanup fails
+const buildExpiry = 24 * time.Hour
+
+// BuildImage bakes a new image: it creates a temporary droplet from base, runs provision on it (e.g. over SSH), powers it off and takes a snapshot called name. The droplet is destroyed afterwards, even if a step fails. If base has no name, the droplet is named after the image, and unless base sets ExpiresAt the droplet expires after a day so ReapExpired catches it if cleanup fails.
+func (c *Client) BuildImage(ctx context.Context, base NewDroplet, provision func(ctx context.Context, d *Droplet) error, name string) (*Image, error) {
+	if name == "" {
+		return nil, fmt.Errorf("image name must be set")
+	}
+
+	if base.Name == "" {
+		base.Name = "build-" + name
+	}
+
+	if base.ExpiresAt.IsZero() {
+		base.ExpiresAt = time.Now().Add(buildExpiry)
+	}
+
+	var image *Image
+	err := c.WithEphemeralDroplet(ctx, base, func(ctx context.Context, d *Droplet) error {
+		if err := provision(ctx, d); err != nil {
+			return fmt.Errorf("could not provision droplet with ID %d: %w", d.ID, err)
+		}
+
+		cc := c.WithContext(ctx)
+
+		eventID, err := cc.PowerOffDroplet(d.ID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := c.WaitForEvent(ctx, eventID); err != nil {
+			return err
+		}
+
+		eventID, err = cc.TakeSnapshotOnDroplet(d.ID, name)
+		if err != nil {
+			return err
+		}
+
+		if _, err := c.WaitForEvent(ctx, eventID); err != nil {
+			return err
+		}
+
+		image, err = cc.getImageByName(name)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return image, nil
+}
+
+// getImageByName returns the newest of the client's own images with the given name
+func (c *Client) getImageByName(name string) (*Image, error) {
+	images, err := c.GetMyImages()
+	if err != nil {
+		return nil, err
+	}
+
+	var image *Image
+	for i := range images {
+		if images[i].Name == name && (image == nil || images[i].ID > image.ID) {
+			image = &images[i]
+		}
+	}
+
+	if image == nil {
+		return nil, fmt.Errorf("could not find image %q: %w", name, ErrNotFound)
+	}
+
+	return image, nil
+}
@@ -0,0 +1,153 @@
+package godo
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// DropletBuilder builds a NewDroplet step by step and checks at Build that the fields fit together, e.g. that a size is given either by ID or by slug
+type DropletBuilder struct {
+	n    NewDroplet
+	errs []error
+}
+
+// NewDropletBuilder returns an empty builder
+func NewDropletBuilder() *DropletBuilder {
+	return &DropletBuilder{}
+}
+
+// From starts from an existing spec, e.g. one read from a file
+func (b *DropletBuilder) From(n NewDroplet) *DropletBuilder {
+	b.n = n
+	b.n.SSHKeyIDs = slices.Clone(n.SSHKeyIDs)
+	return b
+}
+
+// Name sets the name
+func (b *DropletBuilder) Name(name string) *DropletBuilder {
+	b.n.Name = name
+	return b
+}
+
+// SizeID sets the size by ID
+func (b *DropletBuilder) SizeID(ID int) *DropletBuilder {
+	b.n.SizeID = ID
+	return b
+}
+
+// SizeSlug sets the size by slug, e.g. "512mb"
+func (b *DropletBuilder) SizeSlug(slug string) *DropletBuilder {
+	b.n.SizeSlug = slug
+	return b
+}
+
+// ImageID sets the image by ID
+func (b *DropletBuilder) ImageID(ID int) *DropletBuilder {
+	b.n.ImageID = ID
+	return b
+}
+
+// ImageSlug sets the image by slug, e.g. "ubuntu-14-04-x64"
+func (b *DropletBuilder) ImageSlug(slug string) *DropletBuilder {
+	b.n.ImageSlug = slug
+	return b
+}
+
+// RegionID sets the region by ID
+func (b *DropletBuilder) RegionID(ID int) *DropletBuilder {
+	b.n.RegionID = ID
+	return b
+}
+
+// RegionSlug sets the region by slug, e.g. "nyc2"
+func (b *DropletBuilder) RegionSlug(slug string) *DropletBuilder {
+	b.n.RegionSlug = slug
+	return b
+}
+
+// SSHKeys adds SSH keys by ID
+func (b *DropletBuilder) SSHKeys(IDs ...int) *DropletBuilder {
+	for _, ID := range IDs {
+		if ID <= 0 {
+			b.errs = append(b.errs, fmt.Errorf("SSH key ID %d is invalid", ID))
+			continue
+		}
+		b.n.SSHKeyIDs = append(b.n.SSHKeyIDs, strconv.Itoa(ID))
+	}
+
+	return b
+}
+
+// PrivateNetworking enables private networking
+func (b *DropletBuilder) PrivateNetworking() *DropletBuilder {
+	b.n.PrivateNetworking = true
+	return b
+}
+
+// Backups enables automatic backups
+func (b *DropletBuilder) Backups() *DropletBuilder {
+	b.n.BackupsEnabled = true
+	return b
+}
+
+// Identity sets the logical identity, see WithIdentity
+func (b *DropletBuilder) Identity(identity string) *DropletBuilder {
+	b.n.Identity = identity
+	return b
+}
+
+// ExpiresAt sets the deadline after which ReapExpired destroys the droplet
+func (b *DropletBuilder) ExpiresAt(deadline time.Time) *DropletBuilder {
+	b.n.ExpiresAt = deadline
+	return b
+}
+
+// ExpiresIn sets the deadline after which ReapExpired destroys the droplet relative to now
+func (b *DropletBuilder) ExpiresIn(d time.Duration) *DropletBuilder {
+	return b.ExpiresAt(time.Now().Add(d))
+}
+
+// Build returns the spec, or all the problems found in it joined
+func (b *DropletBuilder) Build() (NewDroplet, error) {
+	errs := append([]error(nil), b.errs...)
+	n := b.n
+
+	if n.Name == "" {
+		errs = append(errs, fmt.Errorf("name must be set"))
+	}
+
+	errs = append(errs,
+		exactlyOne("size", n.SizeID, n.SizeSlug),
+		exactlyOne("image", n.ImageID, n.ImageSlug),
+		exactlyOne("region", n.RegionID, n.RegionSlug),
+	)
+
+	if n.Identity != "" {
+		errs = append(errs, validIdentity(n.Identity))
+	}
+
+	if !n.ExpiresAt.IsZero() && !n.ExpiresAt.After(time.Now()) {
+		errs = append(errs, fmt.Errorf("expiry %s is in the past", n.ExpiresAt.Format(time.RFC3339)))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return NewDroplet{}, fmt.Errorf("invalid droplet %q: %w", n.Name, err)
+	}
+
+	return n, nil
+}
+
+// exactlyOne returns an error unless a resource is given either by ID or by slug
+func exactlyOne(resource string, ID int, slug string) error {
+	switch {
+	case ID == 0 && slug == "":
+		return fmt.Errorf("%s ID or slug must be set", resource)
+	case ID != 0 && slug != "":
+		return fmt.Errorf("%s must be set either by ID (%d) or by slug (%q), not both", resource, ID, slug)
+	}
+
+	return nil
+}
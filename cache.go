@@ -0,0 +1,158 @@
+package godo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTLs are the TTLs of the read endpoints cached by WithCache by default, by endpoint family as in Capabilities
+var DefaultCacheTTLs = map[string]time.Duration{
+	"/regions":     time.Hour,
+	"/sizes":       time.Hour,
+	"/images":      10 * time.Minute,
+	"/images/{id}": 10 * time.Minute,
+}
+
+// Cache stores response bodies. Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns a body which hasn't expired
+	Get(key string) ([]byte, bool)
+	Set(key string, body []byte, ttl time.Duration)
+	// Invalidate removes all bodies whose key starts with prefix
+	Invalidate(prefix string)
+}
+
+// MemoryCache is a Cache which keeps bodies in memory
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	body    []byte
+	expires time.Time
+}
+
+// NewMemoryCache returns an empty MemoryCache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string]cacheEntry)}
+}
+
+// Get returns a body which hasn't expired
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(e.expires) {
+		delete(m.entries, key)
+		return nil, false
+	}
+
+	return e.body, true
+}
+
+// Set stores a body for ttl
+func (m *MemoryCache) Set(key string, body []byte, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries[key] = cacheEntry{body: body, expires: time.Now().Add(ttl)}
+}
+
+// Invalidate removes all bodies whose key starts with prefix
+func (m *MemoryCache) Invalidate(prefix string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key := range m.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(m.entries, key)
+		}
+	}
+}
+
+// responseCache is the cache of a client with the TTLs of the endpoints it caches
+type responseCache struct {
+	cache Cache
+	ttls  map[string]time.Duration
+}
+
+// WithCache caches the successful responses of the read endpoints in ttls, keyed by endpoint family, e.g. "/images/{id}". If ttls is nil, DefaultCacheTTLs is used. Use a cache for one account only: the keys don't include the credentials.
+func WithCache(cache Cache, ttls map[string]time.Duration) Option {
+	return func(c *Client) {
+		if cache == nil {
+			c.cache = nil
+			return
+		}
+
+		if ttls == nil {
+			ttls = DefaultCacheTTLs
+		}
+
+		c.cache = &responseCache{cache: cache, ttls: ttls}
+	}
+}
+
+// InvalidateCache removes the cached responses of endpoints and the endpoints below them, e.g. InvalidateCache("/images"). Mutating calls of the client invalidate the responses they affect themselves, so this is only needed after changes made elsewhere, e.g. in the control panel. Without endpoints, the whole cache is cleared.
+func (c *Client) InvalidateCache(endpoints ...string) {
+	if c.cache == nil {
+		return
+	}
+
+	if len(endpoints) == 0 {
+		c.cache.cache.Invalidate("")
+		return
+	}
+
+	for _, endpoint := range endpoints {
+		c.cache.cache.Invalidate(endpoint)
+	}
+}
+
+// invalidatedBy returns the endpoints whose cached responses a mutating call to endpoint may make stale. A call invalidates the resources of its own kind, and actions on droplets which add or remove images, e.g. a snapshot, also invalidate the images.
+func invalidatedBy(endpoint string) []string {
+	segments := strings.Split(strings.TrimPrefix(endpoint, "/"), "/")
+	endpoints := []string{"/" + segments[0]}
+
+	if segments[0] == "droplets" {
+		switch segments[len(segments)-1] {
+		case "snapshot", "destroy", "rebuild", "restore":
+			endpoints = append(endpoints, "/images")
+		}
+	}
+
+	return endpoints
+}
+
+// cacheKey returns the key of a call and its TTL, which is 0 if the call isn't cached
+func (rc *responseCache) cacheKey(method, endpoint string, params url.Values) (string, time.Duration) {
+	if rc == nil || method != http.MethodGet {
+		return "", 0
+	}
+
+	ttl := rc.ttls[endpointFamily(endpoint)]
+	if ttl <= 0 {
+		return "", 0
+	}
+
+	return endpoint + "?" + params.Encode(), ttl
+}
+
+// store caches a body unless the API reported an error in it
+func (rc *responseCache) store(key string, body []byte, ttl time.Duration) {
+	var e envelope
+	if json.Unmarshal(body, &e) != nil || e.Status != StatusOK {
+		return
+	}
+
+	rc.cache.Set(key, body, ttl)
+}
@@ -0,0 +1,69 @@
+package godo
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCacheInvalidatedBySnapshot(t *testing.T) {
+	var snapshotted atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/droplets/1/snapshot":
+			snapshotted.Store(true)
+			fmt.Fprint(w, `{"status":"OK","event_id":2}`)
+		case "/images":
+			if snapshotted.Load() {
+				fmt.Fprint(w, `{"status":"OK","images":[{"id":3,"name":"pre-deploy"}]}`)
+				return
+			}
+			fmt.Fprint(w, `{"status":"OK","images":[]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithCredentials("id", "key"), WithBaseURL(srv.URL), WithCache(NewMemoryCache(), nil))
+
+	if _, err := c.getImageByName("pre-deploy"); err == nil {
+		t.Fatal("found image before the snapshot was taken")
+	}
+
+	if _, err := c.TakeSnapshotOnDroplet(1, "pre-deploy"); err != nil {
+		t.Fatalf("could not take snapshot: %v", err)
+	}
+
+	image, err := c.getImageByName("pre-deploy")
+	if err != nil {
+		t.Fatalf("could not find the snapshot after taking it: %v", err)
+	}
+	if image.ID != 3 {
+		t.Errorf("got image %d, want 3", image.ID)
+	}
+}
+
+func TestInvalidatedBy(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     []string
+	}{
+		{"/droplets/new", []string{"/droplets"}},
+		{"/droplets/1/reboot", []string{"/droplets"}},
+		{"/droplets/1/snapshot", []string{"/droplets", "/images"}},
+		{"/droplets/1/destroy", []string{"/droplets", "/images"}},
+		{"/droplets/1/rebuild", []string{"/droplets", "/images"}},
+		{"/images/3/destroy", []string{"/images"}},
+		{"/domains/example.com/records/new", []string{"/domains"}},
+	}
+
+	for _, tt := range tests {
+		got := invalidatedBy(tt.endpoint)
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("invalidatedBy(%q) = %v, want %v", tt.endpoint, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// CancelReason tells why a request was canceled
+type CancelReason string
+
+const (
+	// CanceledByCaller means the caller's context was canceled
+	CanceledByCaller CancelReason = "canceled by caller"
+	// CanceledByDeadline means a deadline or timeout set on the client side passed
+	CanceledByDeadline CancelReason = "deadline exceeded"
+	// CanceledByServerTimeout means the server or a gateway in front of it gave up on the request
+	CanceledByServerTimeout CancelReason = "server timeout"
+)
+
+// CanceledError is returned when a request did not complete because it was canceled
+type CanceledError struct {
+	Reason   CancelReason
+	Endpoint string
+	Err      error
+}
+
+func (e *CanceledError) Error() string {
+	return fmt.Sprintf("request to %s canceled: %s: %v", e.Endpoint, e.Reason, e.Err)
+}
+
+func (e *CanceledError) Unwrap() error {
+	return e.Err
+}
+
+// WithRequestTimeout returns a copy of the client whose requests each time out after d, which is useful for calls that should fail faster than the client default
+func (c *Client) WithRequestTimeout(d time.Duration) *Client {
+	cc := *c
+	cc.requestTimeout = d
+	return &cc
+}
+
+// cancelError wraps err in a CanceledError if the request failed because it was canceled. parent is the caller's context.
+func cancelError(parent context.Context, endpoint string, err error) error {
+	var ce *CanceledError
+	if err == nil || errors.As(err, &ce) {
+		return err
+	}
+
+	if errors.Is(parent.Err(), context.Canceled) {
+		return &CanceledError{Reason: CanceledByCaller, Endpoint: endpoint, Err: err}
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return &CanceledError{Reason: CanceledByDeadline, Endpoint: endpoint, Err: err}
+	}
+
+	return err
+}
+
+// serverTimeout returns a CanceledError if the response reports that the server timed out
+func serverTimeout(endpoint string, resp *http.Response) error {
+	if resp.StatusCode != http.StatusGatewayTimeout && resp.StatusCode != http.StatusRequestTimeout {
+		return nil
+	}
+
+	return &CanceledError{
+		Reason:   CanceledByServerTimeout,
+		Endpoint: endpoint,
+		Err:      fmt.Errorf("server responded with %s", resp.Status),
+	}
+}
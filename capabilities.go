@@ -0,0 +1,98 @@
+package godo
+
+import "net/http"
+
+// APIVersion is the version of the DigitalOcean API which this client implements
+const APIVersion = "v1"
+
+// Capability describes an API endpoint which this client version implements
+type Capability struct {
+	// Method is the name of the Client method which wraps the endpoint
+	Method string
+	// HTTPMethod and Endpoint identify the API call, with {id} standing in for IDs
+	HTTPMethod string
+	Endpoint   string
+	APIVersion string
+	// Mutating is true if the call changes resources
+	Mutating bool
+}
+
+// capabilities maps Client methods to the endpoints they call
+var capabilities = []struct{ method, endpoint string }{
+	{"GetEventByID", "/events/{id}"},
+	{"GetAllRegions", "/regions"},
+	{"GetAllSizes", "/sizes"},
+
+	{"CreateDroplet", "/droplets/new"},
+	{"GetAllDroplets", "/droplets"},
+	{"GetAllDropletsConcurrently", "/droplets"},
+	{"GetDropletByID", "/droplets/{id}"},
+	{"GetDropletByName", "/droplets"},
+	{"ListDroplets", "/droplets"},
+	{"GetDropletSnapshots", "/droplets/{id}"},
+	{"GetDropletBackups", "/droplets/{id}"},
+	{"DeleteDropletByID", "/droplets/{id}/destroy"},
+	{"RebootDroplet", "/droplets/{id}/reboot"},
+	{"PowerCycleDroplet", "/droplets/{id}/power_cycle"},
+	{"ShutDownDroplet", "/droplets/{id}/shutdown"},
+	{"PowerOffDroplet", "/droplets/{id}/power_off"},
+	{"PowerOnDroplet", "/droplets/{id}/power_on"},
+	{"ResetRootPassDroplet", "/droplets/{id}/password_reset"},
+	{"ResizeDroplet", "/droplets/{id}/resize"},
+	{"ResizeDropletWithOptions", "/droplets/{id}/resize"},
+	{"TakeSnapshotOnDroplet", "/droplets/{id}/snapshot"},
+	{"RestoreDroplet", "/droplets/{id}/restore"},
+	{"RebuildDroplet", "/droplets/{id}/rebuild"},
+	{"RenameDroplet", "/droplets/{id}/rename"},
+	{"EnableBackupsOnDroplet", "/droplets/{id}/enable_backups"},
+	{"DisableBackupsOnDroplet", "/droplets/{id}/disable_backups"},
+
+	{"GetAllImages", "/images"},
+	{"GetMyImages", "/images"},
+	{"GetImageByID", "/images/{id}"},
+	{"DeleteImage", "/images/{id}/destroy"},
+	{"TransferImage", "/images/{id}/transfer"},
+
+	{"CreateDomain", "/domains/new"},
+	{"GetAllDomains", "/domains"},
+	{"GetDomainByID", "/domains/{id}"},
+	{"DeleteDomainByID", "/domains/{id}/destroy"},
+	{"CreateDomainRecord", "/domains/{id}/records/new"},
+	{"GetAllRecordsByDomain", "/domains/{id}/records"},
+	{"GetRecordByDomain", "/domains/{id}/records/{id}"},
+	{"UpdateRecordByDomain", "/domains/{id}/records/{id}/edit"},
+	{"DeleteRecordByDomain", "/domains/{id}/records/{id}/destroy"},
+
+	{"GetAllSSHKeys", "/ssh_keys"},
+	{"GetSSHKeyByID", "/ssh_keys/{id}"},
+	{"CreateSSHKey", "/ssh_keys/new"},
+	{"UpdateSSHKey", "/ssh_keys/{id}/edit"},
+	{"DeleteSSHKey", "/ssh_keys/{id}/destroy"},
+}
+
+// Capabilities lists the API endpoints which this client version implements, so tools can check for an endpoint before relying on it. Every v1 call is a GET; Mutating tells the ones which change resources apart.
+func Capabilities() []Capability {
+	caps := make([]Capability, len(capabilities))
+	for i, c := range capabilities {
+		caps[i] = Capability{
+			Method:     c.method,
+			HTTPMethod: http.MethodGet,
+			Endpoint:   c.endpoint,
+			APIVersion: APIVersion,
+			Mutating:   isMutating(http.MethodGet, c.endpoint),
+		}
+	}
+
+	return caps
+}
+
+// Implements reports whether this client version has a Client method with the given name which wraps an API endpoint, e.g. Implements("RebootDroplet")
+func Implements(method string) bool {
+	for _, c := range capabilities {
+		if c.method == method {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,209 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// CloneOptions configures CloneDroplet. The clone gets the size and region of the source unless they are overridden.
+type CloneOptions struct {
+	// PowerOff powers the source off for the snapshot, so its disk is consistent, and on again afterwards. A source which is already off is left off.
+	PowerOff bool
+
+	SizeID   int
+	SizeSlug string
+
+	RegionID   int
+	RegionSlug string
+
+	// DeleteSnapshot deletes the snapshot the clone was created from once it is active
+	DeleteSnapshot bool
+}
+
+// CloneDroplet creates a copy of a droplet named newName from a snapshot of it and waits until the copy is active. It runs as a Workflow whose steps record each resource as soon as it is requested, so when a step fails everything requested so far is undone: the source is powered on again and the snapshot and the copy are deleted. The snapshot is named with NameSnapshot. Deleting it with DeleteSnapshot is best effort: if that fails, the copy is kept and returned together with the error.
+func (c *Client) CloneDroplet(ctx context.Context, ID int, newName string, opts CloneOptions) (*Droplet, error) {
+	if newName == "" {
+		return nil, fmt.Errorf("name of the clone must be set")
+	}
+
+	src, err := c.WithContext(ctx).GetDropletByID(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := NameSnapshot(*src, time.Now())
+	powerOff := opts.PowerOff && !src.Status.IsOff()
+
+	// waitFor waits for the event stored under key, if any
+	waitFor := func(ctx context.Context, c *Client, st *WorkflowState, key string) error {
+		if st.Get(key) == "" {
+			return nil
+		}
+
+		_, err := c.WaitForEvent(ctx, st.Int(key))
+		return err
+	}
+
+	var clone *Droplet
+	wf := NewWorkflow("clone",
+		WorkflowStep{
+			Name: "power off",
+			Run: func(ctx context.Context, c *Client, st *WorkflowState) (int, error) {
+				if !powerOff {
+					return 0, nil
+				}
+
+				eventID, err := c.WithContext(ctx).PowerOffDroplet(ID)
+				if err != nil {
+					return 0, err
+				}
+				st.SetInt("power off event", eventID)
+
+				return 0, nil
+			},
+			Compensate: func(ctx context.Context, c *Client, st *WorkflowState) error {
+				if !powerOff {
+					return nil
+				}
+
+				return c.waitForAction(ctx, func(c *Client) (int, error) { return c.PowerOnDroplet(ID) })
+			},
+		},
+		WorkflowStep{
+			Name: "snapshot",
+			Run: func(ctx context.Context, c *Client, st *WorkflowState) (int, error) {
+				if err := waitFor(ctx, c, st, "power off event"); err != nil {
+					return 0, err
+				}
+
+				eventID, err := c.WithContext(ctx).TakeSnapshotOnDroplet(ID, snapshot)
+				if err != nil {
+					return 0, err
+				}
+				st.SetInt("snapshot event", eventID)
+
+				return 0, nil
+			},
+			Compensate: func(ctx context.Context, c *Client, st *WorkflowState) error {
+				imageID := st.Int("image")
+				if imageID == 0 {
+					if c.DryRun() {
+						return nil
+					}
+
+					// The snapshot may exist even though its event wasn't waited for
+					image, err := c.WithContext(ctx).getImageByName(snapshot)
+					if errors.Is(err, ErrNotFound) {
+						return nil
+					}
+					if err != nil {
+						return err
+					}
+					imageID = image.ID
+				}
+
+				return c.WithContext(ctx).DeleteImage(imageID)
+			},
+		},
+		WorkflowStep{
+			Name: "find snapshot",
+			Run: func(ctx context.Context, c *Client, st *WorkflowState) (int, error) {
+				if err := waitFor(ctx, c, st, "snapshot event"); err != nil {
+					return 0, err
+				}
+
+				if c.DryRun() {
+					// The snapshot doesn't exist, so the plan refers to it by name
+					return 0, nil
+				}
+
+				image, err := c.WithContext(ctx).getImageByName(snapshot)
+				if err != nil {
+					return 0, err
+				}
+				st.SetInt("image", image.ID)
+
+				return 0, nil
+			},
+		},
+		WorkflowStep{
+			Name: "power on",
+			Run: func(ctx context.Context, c *Client, st *WorkflowState) (int, error) {
+				if !powerOff {
+					return 0, nil
+				}
+
+				return c.WithContext(ctx).PowerOnDroplet(ID)
+			},
+		},
+		WorkflowStep{
+			Name: "create",
+			Run: func(ctx context.Context, c *Client, st *WorkflowState) (int, error) {
+				n := NewDroplet{
+					Name:              newName,
+					ImageID:           st.Int("image"),
+					SizeID:            src.SizeID,
+					RegionID:          src.RegionID,
+					PrivateNetworking: src.PrivateIPAddress != "",
+					BackupsEnabled:    src.BackupsActive,
+				}
+				if n.ImageID == 0 {
+					n.ImageSlug = snapshot
+				}
+				if opts.SizeID != 0 || opts.SizeSlug != "" {
+					n.SizeID, n.SizeSlug = opts.SizeID, opts.SizeSlug
+				}
+				if opts.RegionID != 0 || opts.RegionSlug != "" {
+					n.RegionID, n.RegionSlug = opts.RegionID, opts.RegionSlug
+				}
+
+				pd, err := c.WithContext(ctx).CreateDroplet(n)
+				if err != nil {
+					return 0, err
+				}
+				st.SetInt("droplet", pd.ID)
+				st.SetInt("create event", pd.EventID)
+
+				return 0, nil
+			},
+			Compensate: func(ctx context.Context, c *Client, st *WorkflowState) error {
+				if st.Int("droplet") == 0 {
+					return nil
+				}
+
+				return c.waitForAction(ctx, func(c *Client) (int, error) { return c.DeleteDropletByID(st.Int("droplet")) })
+			},
+		},
+		WorkflowStep{
+			Name: "wait for clone",
+			Run: func(ctx context.Context, c *Client, st *WorkflowState) (int, error) {
+				if err := waitFor(ctx, c, st, "create event"); err != nil {
+					return 0, err
+				}
+
+				d, err := c.WaitForDropletStatus(ctx, st.Int("droplet"), DropletStatusActive)
+				if err != nil {
+					return 0, err
+				}
+				clone = d
+
+				return 0, nil
+			},
+		},
+	)
+
+	st := &WorkflowState{}
+	if err := wf.Run(ctx, c, st); err != nil {
+		return nil, err
+	}
+
+	if opts.DeleteSnapshot && (st.Int("image") != 0 || c.DryRun()) {
+		if err := c.WithContext(ctx).DeleteImage(st.Int("image")); err != nil {
+			return clone, fmt.Errorf("clone %q was created but its snapshot %q could not be deleted: %w", newName, snapshot, err)
+		}
+	}
+
+	return clone, nil
+}
@@ -0,0 +1,123 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// CopySelector selects the resources which Copy copies. A nil function selects none of that kind.
+type CopySelector struct {
+	Domains func(Domain) bool
+	SSHKeys func(SSHKey) bool
+}
+
+// CopyReport lists what Copy did, or would do in preview mode
+type CopyReport struct {
+	Domains []SyncReport
+	// SSHKeys maps the names of the copied keys to their IDs in the destination account
+	SSHKeys map[string]int
+	// Plan lists the mutating calls in preview mode
+	Plan []PlannedCall
+}
+
+// Copy copies the selected SSH keys and domains with their records from the account of src to the account of dst, e.g. to merge accounts or clone an environment. SSH keys and domains are converged with EnsureSSHKey and EnsureDomain, so copying again doesn't duplicate them. Droplets aren't copied: that takes a snapshot visible to dst, and the v1 API can't share images between accounts. Within one account, see CloneDroplet. In preview mode nothing is changed and the report's Plan lists the calls which would be made. Copying continues after a resource fails and all errors are returned joined.
+func Copy(ctx context.Context, src, dst *Client, sel CopySelector, preview bool) (*CopyReport, error) {
+	if preview {
+		src = src.dryRunCopy()
+		dst = dst.dryRunCopy()
+	}
+
+	report := &CopyReport{SSHKeys: make(map[string]int)}
+	var errs []error
+
+	if sel.SSHKeys != nil {
+		keys, err := src.WithContext(ctx).GetAllSSHKeys()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, k := range keys {
+			if !sel.SSHKeys(k) {
+				continue
+			}
+
+			ID, err := copySSHKey(ctx, src, dst, k)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("could not copy SSH key %q: %w", k.Name, err))
+				continue
+			}
+
+			report.SSHKeys[k.Name] = ID
+		}
+	}
+
+	if sel.Domains != nil {
+		domains, err := src.WithContext(ctx).GetAllDomains()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, d := range domains {
+			if !sel.Domains(d) {
+				continue
+			}
+
+			sr, err := copyDomain(ctx, src, dst, d)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("could not copy domain %q: %w", d.Name, err))
+				continue
+			}
+
+			report.Domains = append(report.Domains, *sr)
+		}
+	}
+
+	if preview {
+		report.Plan = append(src.Plan(), dst.Plan()...)
+	}
+
+	return report, errors.Join(errs...)
+}
+
+func copySSHKey(ctx context.Context, src, dst *Client, k SSHKey) (int, error) {
+	key, err := src.WithContext(ctx).GetSSHKeyByID(k.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	return dst.EnsureSSHKey(ctx, key.Name, key.PublicKey)
+}
+
+func copyDomain(ctx context.Context, src, dst *Client, d Domain) (*SyncReport, error) {
+	records, err := src.WithContext(ctx).GetAllRecordsByDomain(d.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		baseIP net.IP
+		copies []DomainRecord
+	)
+	for _, r := range records {
+		if unmanagedRecordTypes[r.RecordType] {
+			continue
+		}
+
+		if r.RecordType == "A" && r.Name == "@" && baseIP == nil {
+			baseIP = net.ParseIP(r.Data)
+		}
+
+		copies = append(copies, DomainRecord{
+			RecordType: r.RecordType,
+			Name:       r.Name,
+			Data:       r.Data,
+			Priority:   r.Priority,
+			Port:       r.Port,
+			Weight:     r.Weight,
+		})
+	}
+
+	return dst.EnsureDomain(ctx, d.Name, baseIP, copies)
+}
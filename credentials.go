@@ -0,0 +1,259 @@
+package godo
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultCredentialCooldown is how long credentials of a pool are skipped after the API rejected them
+const DefaultCredentialCooldown = time.Minute
+
+// Credentials is a client ID and API key pair
+type Credentials struct {
+	ClientID string
+	APIKey   string
+}
+
+// credentialPool holds several credentials of one account, sticks with one of them and moves on to the next when the API rejects it
+type credentialPool struct {
+	mu       sync.Mutex
+	creds    []Credentials
+	current  int
+	benched  map[Credentials]time.Time
+	cooldown time.Duration
+}
+
+// WithCredentialPool makes the client fail over between several credentials of one account, e.g. keys which are rotated independently. The client uses the same credentials until the API rejects them with 401 or 429, then they are skipped for DefaultCredentialCooldown and the request is sent again with the next ones. All credentials must belong to the same account: droplet, image and event IDs are only visible to their own account, so e.g. waiting for the event of a droplet created with credentials of another account fails with ErrNotFound. The ClientID and APIKey fields are ignored while a pool is set.
+func WithCredentialPool(creds ...Credentials) Option {
+	return func(c *Client) {
+		if len(creds) == 0 {
+			c.pool = nil
+			return
+		}
+
+		c.pool = &credentialPool{
+			creds:    append([]Credentials(nil), creds...),
+			benched:  make(map[Credentials]time.Time),
+			cooldown: DefaultCredentialCooldown,
+		}
+	}
+}
+
+// pick returns the current credentials unless they are benched, in which case it moves on to the next ones which aren't. If all are benched, it returns those whose cooldown ends first.
+func (p *credentialPool) pick() Credentials {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	best := -1
+	for i := range p.creds {
+		idx := (p.current + i) % len(p.creds)
+		until, ok := p.benched[p.creds[idx]]
+		if !ok || now.After(until) {
+			best = idx
+			break
+		}
+
+		if best < 0 || until.Before(p.benched[p.creds[best]]) {
+			best = idx
+		}
+	}
+
+	p.current = best
+
+	return p.creds[best]
+}
+
+// reject benches credentials if the status means the API rejected them and reports whether it did
+func (p *credentialPool) reject(cred Credentials, status int) bool {
+	if status != http.StatusUnauthorized && status != http.StatusTooManyRequests {
+		return false
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.benched[cred] = time.Now().Add(p.cooldown)
+
+	return true
+}
+
+// standbyCredentials are the credentials a client switches to for good once the API rejects its primary ones
+type standbyCredentials struct {
+	creds    Credentials
+	switched atomic.Bool
+	onSwitch func(from, to Credentials, status int)
+}
+
+// WithStandbyCredentials sets credentials which the client switches to, for all following requests, once the API rejects the primary ones with 401 or 403, e.g. because they were rotated by mistake. The rejected request is sent again with the standby credentials. onSwitch, if not nil, is called once when the client switches. Standby credentials are ignored while a credential pool is set.
+func WithStandbyCredentials(standby Credentials, onSwitch func(from, to Credentials, status int)) Option {
+	return func(c *Client) {
+		c.standby = &standbyCredentials{creds: standby, onSwitch: onSwitch}
+	}
+}
+
+// switchFrom switches to the standby credentials if the primary ones were rejected with status. It reports whether it did.
+func (s *standbyCredentials) switchFrom(primary Credentials, status int) bool {
+	if status != http.StatusUnauthorized && status != http.StatusForbidden {
+		return false
+	}
+
+	if !s.switched.CompareAndSwap(false, true) {
+		// Another request has switched already
+		return true
+	}
+
+	if s.onSwitch != nil {
+		s.onSwitch(primary, s.creds, status)
+	}
+
+	return true
+}
+
+// OnStandby reports whether the client has switched to its standby credentials
+func (c *Client) OnStandby() bool {
+	return c.standby != nil && c.standby.switched.Load()
+}
+
+// reauthenticator fetches fresh credentials when the API rejects the current ones
+type reauthenticator struct {
+	mu    sync.Mutex
+	fn    func(ctx context.Context, rejected Credentials) (Credentials, error)
+	creds *Credentials
+}
+
+// WithReauthentication calls fn when the API rejects a request with 401, so it can supply fresh credentials, e.g. from a secret store. The request is sent again once with the fresh credentials, which are used for all following requests. Concurrent rejections of the same credentials call fn only once.
+func WithReauthentication(fn func(ctx context.Context, rejected Credentials) (Credentials, error)) Option {
+	return func(c *Client) {
+		c.reauth = &reauthenticator{fn: fn}
+	}
+}
+
+// current returns the credentials supplied last, if any
+func (r *reauthenticator) current() (Credentials, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.creds == nil {
+		return Credentials{}, false
+	}
+
+	return *r.creds, true
+}
+
+// refresh replaces rejected credentials unless another request has replaced them already
+func (r *reauthenticator) refresh(ctx context.Context, rejected Credentials) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.creds != nil && *r.creds != rejected {
+		return nil
+	}
+
+	fresh, err := r.fn(ctx, rejected)
+	if err != nil {
+		return err
+	}
+
+	r.creds = &fresh
+	return nil
+}
+
+// credentials returns the credentials for the next request
+func (c *Client) credentials() Credentials {
+	if c.pool != nil {
+		return c.pool.pick()
+	}
+
+	if c.reauth != nil {
+		if cred, ok := c.reauth.current(); ok {
+			return cred
+		}
+	}
+
+	if c.OnStandby() {
+		return c.standby.creds
+	}
+
+	return Credentials{ClientID: c.ClientID, APIKey: c.APIKey}
+}
+
+// sendAttempt tracks how a request has been sent again with other credentials
+type sendAttempt struct {
+	tried     int
+	reauthed  bool
+	onStandby bool
+	// sent is the size of the requests sent, see Stats
+	sent int
+}
+
+// failover reports whether a request which was rejected with status should be sent again with other credentials
+func (c *Client) failover(ctx context.Context, a *sendAttempt, cred Credentials, status int) bool {
+	if c.pool != nil {
+		return a.tried < len(c.pool.creds) && c.pool.reject(cred, status)
+	}
+
+	if c.reauth != nil && status == http.StatusUnauthorized && !a.reauthed {
+		a.reauthed = true
+
+		err := c.reauth.refresh(ctx, cred)
+		if err == nil {
+			return true
+		}
+
+		if c.Logger != nil {
+			c.Logger.WarnContext(ctx, "godo: could not reauthenticate", slog.Any("error", err))
+		}
+	}
+
+	if c.standby != nil && !a.onStandby && cred != c.standby.creds {
+		a.onStandby = true
+		return c.standby.switchFrom(cred, status)
+	}
+
+	return false
+}
+
+// send sends a request once, with the payload as a JSON body if there is one. With a credential pool, a request rejected with 401 or 429 is sent again with each of the other credentials until one is accepted. Otherwise a request rejected with 401 is sent again with fresh credentials if the client reauthenticates, and one rejected with 401 or 403 is sent again with the standby credentials if there are any.
+func (c *Client) send(ctx context.Context, a *sendAttempt, method, endpoint string, params url.Values, payload []byte) (*http.Response, error) {
+	for {
+		a.tried++
+		cred := c.credentials()
+
+		var body io.Reader
+		if payload != nil {
+			body = bytes.NewReader(payload)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, c.buildURL(endpoint, params, cred), body)
+		if err != nil {
+			return nil, redactURLError(err, endpoint, params)
+		}
+
+		a.sent += len(req.URL.RequestURI()) + len(payload)
+
+		req.Header.Set("User-Agent", c.userAgent())
+		req.Header.Set("Accept-Encoding", "gzip")
+		if payload != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := c.roundTrip()(req)
+		if err != nil {
+			return nil, redactURLError(err, endpoint, params)
+		}
+
+		if !c.failover(ctx, a, cred, resp.StatusCode) {
+			return resp, nil
+		}
+
+		resp.Body.Close()
+	}
+}
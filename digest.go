@@ -0,0 +1,147 @@
+package godo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// hoursPerMonth is used to estimate monthly costs from hourly prices
+const hoursPerMonth = 730
+
+// RecordChange is a DNS record which was created, changed or deleted in a domain
+type RecordChange struct {
+	Domain string
+	Record DomainRecord
+}
+
+// Digest summarizes the activity in an account between two inventories
+type Digest struct {
+	From time.Time
+	To   time.Time
+
+	CreatedDroplets   []Droplet
+	DestroyedDroplets []Droplet
+
+	CreatedDomains []Domain
+	DeletedDomains []Domain
+	CreatedRecords []RecordChange
+	ChangedRecords []RecordChange
+	DeletedRecords []RecordChange
+
+	Snapshots     int
+	SnapshotDelta int
+
+	// MonthlyCostDelta is the estimated change of the monthly cost of all droplets
+	MonthlyCostDelta float64
+}
+
+// NewDigest compares two inventories of the same account, e.g. taken a day or a week apart, and summarizes what changed
+func NewDigest(prev, cur *Inventory) *Digest {
+	d := &Digest{
+		From:             prev.TakenAt,
+		To:               cur.TakenAt,
+		Snapshots:        len(cur.Images),
+		SnapshotDelta:    len(cur.Images) - len(prev.Images),
+		MonthlyCostDelta: (cur.costPerHour() - prev.costPerHour()) * hoursPerMonth,
+	}
+
+	prevDroplets := make(map[int]bool, len(prev.Droplets))
+	for _, dr := range prev.Droplets {
+		prevDroplets[dr.ID] = true
+	}
+	curDroplets := make(map[int]bool, len(cur.Droplets))
+	for _, dr := range cur.Droplets {
+		curDroplets[dr.ID] = true
+		if !prevDroplets[dr.ID] {
+			d.CreatedDroplets = append(d.CreatedDroplets, dr)
+		}
+	}
+	for _, dr := range prev.Droplets {
+		if !curDroplets[dr.ID] {
+			d.DestroyedDroplets = append(d.DestroyedDroplets, dr)
+		}
+	}
+
+	prevDomains := make(map[int]Domain, len(prev.Domains))
+	for _, dom := range prev.Domains {
+		prevDomains[dom.ID] = dom
+	}
+	curDomains := make(map[int]Domain, len(cur.Domains))
+	for _, dom := range cur.Domains {
+		curDomains[dom.ID] = dom
+		if _, ok := prevDomains[dom.ID]; !ok {
+			d.CreatedDomains = append(d.CreatedDomains, dom)
+		}
+		d.diffRecords(dom.Name, prev.Records[dom.ID], cur.Records[dom.ID])
+	}
+	for _, dom := range prev.Domains {
+		if _, ok := curDomains[dom.ID]; !ok {
+			d.DeletedDomains = append(d.DeletedDomains, dom)
+		}
+	}
+
+	return d
+}
+
+func (d *Digest) diffRecords(domain string, prev, cur []DomainRecord) {
+	prevRecords := make(map[int]DomainRecord, len(prev))
+	for _, r := range prev {
+		prevRecords[r.ID] = r
+	}
+	curRecords := make(map[int]bool, len(cur))
+	for _, r := range cur {
+		curRecords[r.ID] = true
+
+		old, ok := prevRecords[r.ID]
+		switch {
+		case !ok:
+			d.CreatedRecords = append(d.CreatedRecords, RecordChange{domain, r})
+		case old != r:
+			d.ChangedRecords = append(d.ChangedRecords, RecordChange{domain, r})
+		}
+	}
+	for _, r := range prev {
+		if !curRecords[r.ID] {
+			d.DeletedRecords = append(d.DeletedRecords, RecordChange{domain, r})
+		}
+	}
+}
+
+// Markdown renders the digest as Markdown, e.g. for posting to a team channel
+func (d *Digest) Markdown() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# DigitalOcean activity %s – %s\n\n", d.From.Format("2006-01-02 15:04"), d.To.Format("2006-01-02 15:04"))
+
+	b.WriteString("## Droplets\n\n")
+	fmt.Fprintf(&b, "- Created: %d\n", len(d.CreatedDroplets))
+	for _, dr := range d.CreatedDroplets {
+		fmt.Fprintf(&b, "  - %s (%d)\n", dr.Name, dr.ID)
+	}
+	fmt.Fprintf(&b, "- Destroyed: %d\n", len(d.DestroyedDroplets))
+	for _, dr := range d.DestroyedDroplets {
+		fmt.Fprintf(&b, "  - %s (%d)\n", dr.Name, dr.ID)
+	}
+
+	b.WriteString("\n## DNS\n\n")
+	fmt.Fprintf(&b, "- Domains created: %d, deleted: %d\n", len(d.CreatedDomains), len(d.DeletedDomains))
+	writeRecordChanges(&b, "Records created", d.CreatedRecords)
+	writeRecordChanges(&b, "Records changed", d.ChangedRecords)
+	writeRecordChanges(&b, "Records deleted", d.DeletedRecords)
+
+	b.WriteString("\n## Snapshots\n\n")
+	fmt.Fprintf(&b, "- Total: %d (%+d)\n", d.Snapshots, d.SnapshotDelta)
+
+	b.WriteString("\n## Cost\n\n")
+	fmt.Fprintf(&b, "- Estimated monthly change: %+.2f USD\n", d.MonthlyCostDelta)
+
+	return b.String()
+}
+
+func writeRecordChanges(b *strings.Builder, title string, changes []RecordChange) {
+	fmt.Fprintf(b, "- %s: %d\n", title, len(changes))
+	for _, c := range changes {
+		fmt.Fprintf(b, "  - %s %s.%s %s\n", c.Record.RecordType, c.Record.Name, c.Domain, c.Record.Data)
+	}
+}
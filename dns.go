@@ -0,0 +1,160 @@
+package godo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DropletRecordName returns the name of the DNS record for a droplet in zone. Every "{name}" in pattern is replaced by the droplet name, with the zone stripped if the droplet is named by its FQDN. An empty pattern means "{name}".
+func DropletRecordName(d Droplet, zone, pattern string) string {
+	if pattern == "" {
+		pattern = "{name}"
+	}
+
+	name := strings.TrimSuffix(d.Name, "."+zone)
+
+	return strings.Replace(pattern, "{name}", name, -1)
+}
+
+// RegisterDropletDNS waits until the droplet is active and then creates or updates an A record for it in zone, named after the droplet as described by DropletRecordName. Droplets only have IPv4 addresses in the v1 API, so no AAAA record is created.
+func (c *Client) RegisterDropletDNS(ctx context.Context, d Droplet, zone, pattern string) (*DomainRecord, error) {
+	active, err := c.WaitForDropletStatus(ctx, d.ID, DropletStatusActive)
+	if err != nil {
+		return nil, err
+	}
+
+	if active.IPAdress == "" {
+		return nil, fmt.Errorf("droplet with ID %d has no IP address", d.ID)
+	}
+
+	return c.upsertRecord(ctx, zone, DomainRecord{
+		RecordType: "A",
+		Name:       DropletRecordName(*active, zone, pattern),
+		Data:       active.IPAdress,
+	})
+}
+
+// UnregisterDropletDNS deletes the A records created for the droplet by RegisterDropletDNS
+func (c *Client) UnregisterDropletDNS(ctx context.Context, d Droplet, zone, pattern string) error {
+	cc := c.WithContext(ctx)
+	name := DropletRecordName(d, zone, pattern)
+
+	records, err := cc.GetAllRecordsByDomain(zone)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range records {
+		if r.RecordType == "A" && r.Name == name {
+			if err := cc.DeleteRecordByDomain(zone, r.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// RunDropletDNS keeps the A records of the droplets seen by the watcher in zone up to date, until ctx is done or the watcher stops: records are registered with RegisterDropletDNS for droplets which appear or change their address and unregistered with UnregisterDropletDNS for droplets which disappear. Subscribe before the watcher is run to register the existing droplets too. The watcher must be run separately. Failures are passed to onError, if it is not nil, and don't stop the sync.
+func RunDropletDNS(ctx context.Context, w *DropletWatcher, zone, pattern string, onError func(e DropletEvent, err error)) error {
+	sub := w.Subscribe(64, Block)
+	defer sub.Close()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		pending = make(map[int]context.CancelFunc)
+	)
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	fail := func(e DropletEvent, err error) {
+		if onError != nil && err != nil {
+			onError(e, err)
+		}
+	}
+
+	// Registering waits until the droplet is active, so it runs in the background and is canceled if the droplet disappears meanwhile
+	register := func(e DropletEvent) {
+		regCtx, regCancel := context.WithCancel(ctx)
+
+		mu.Lock()
+		if prev, ok := pending[e.Droplet.ID]; ok {
+			prev()
+		}
+		pending[e.Droplet.ID] = regCancel
+		mu.Unlock()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer regCancel()
+
+			_, err := w.client.RegisterDropletDNS(regCtx, e.Droplet, zone, pattern)
+			if regCtx.Err() == nil {
+				fail(e, err)
+			}
+		}()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e, ok := <-sub.C:
+			if !ok {
+				return nil
+			}
+
+			switch e.Type {
+			case DropletAdded:
+				register(e)
+			case DropletUpdated:
+				if e.Droplet.IPAdress != e.Previous.IPAdress || e.Droplet.Name != e.Previous.Name {
+					if e.Droplet.Name != e.Previous.Name {
+						fail(e, w.client.UnregisterDropletDNS(ctx, e.Previous, zone, pattern))
+					}
+					register(e)
+				}
+			case DropletRemoved:
+				mu.Lock()
+				if regCancel, ok := pending[e.Droplet.ID]; ok {
+					regCancel()
+					delete(pending, e.Droplet.ID)
+				}
+				mu.Unlock()
+
+				fail(e, w.client.UnregisterDropletDNS(ctx, e.Droplet, zone, pattern))
+			}
+		}
+	}
+}
+
+// upsertRecord updates the first record in zone with the same type and name as r, or creates r if there is none
+func (c *Client) upsertRecord(ctx context.Context, zone string, r DomainRecord) (*DomainRecord, error) {
+	cc := c.WithContext(ctx)
+
+	records, err := cc.GetAllRecordsByDomain(zone)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, existing := range records {
+		if existing.RecordType != r.RecordType || existing.Name != r.Name {
+			continue
+		}
+
+		if existing.Data == r.Data {
+			return &existing, nil
+		}
+
+		r.ID = existing.ID
+		return cc.UpdateRecordByDomain(zone, r)
+	}
+
+	return cc.CreateDomainRecord(zone, r)
+}
@@ -0,0 +1,166 @@
+package godo
+
+import (
+	"context"
+	"net"
+)
+
+// SyncReport lists the changes made to converge the records of a domain
+type SyncReport struct {
+	Domain        string
+	DomainCreated bool
+	Created       []DomainRecord
+	Updated       []DomainRecord
+	Deleted       []DomainRecord
+}
+
+// Changed reports whether anything was changed
+func (r *SyncReport) Changed() bool {
+	return r.DomainCreated || len(r.Created) > 0 || len(r.Updated) > 0 || len(r.Deleted) > 0
+}
+
+// unmanagedRecordTypes are the records maintained by DigitalOcean which SyncRecords never deletes
+var unmanagedRecordTypes = map[string]bool{
+	"NS":  true,
+	"SOA": true,
+}
+
+// sameRecord reports whether two records have the same content, regardless of their IDs
+func sameRecord(a, b DomainRecord) bool {
+	return a.RecordType == b.RecordType &&
+		a.Name == b.Name &&
+		a.Data == b.Data &&
+		a.Priority == b.Priority &&
+		a.Port == b.Port &&
+		a.Weight == b.Weight
+}
+
+// SyncRecords converges the records of a domain to records: identical records are kept, a record with the same type and name but different content is updated, missing records are created and all other records are deleted, except NS and SOA records.
+func (c *Client) SyncRecords(ctx context.Context, domain string, records []DomainRecord) (*SyncReport, error) {
+	existing, err := c.WithContext(ctx).GetAllRecordsByDomain(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SyncReport{Domain: domain}
+
+	return report, c.syncRecords(ctx, report, existing, records)
+}
+
+func (c *Client) syncRecords(ctx context.Context, report *SyncReport, existing, records []DomainRecord) error {
+	cc := c.WithContext(ctx)
+
+	// Keep identical records first so that updates don't clobber them when a type and name have several records, e.g. MX
+	var missing []DomainRecord
+	for _, want := range records {
+		i := -1
+		for j, have := range existing {
+			if sameRecord(have, want) {
+				i = j
+				break
+			}
+		}
+
+		if i < 0 {
+			missing = append(missing, want)
+			continue
+		}
+
+		existing = append(existing[:i], existing[i+1:]...)
+	}
+
+	for _, want := range missing {
+		i := -1
+		for j, have := range existing {
+			if have.RecordType == want.RecordType && have.Name == want.Name {
+				i = j
+				break
+			}
+		}
+
+		if i < 0 {
+			r, err := cc.CreateDomainRecord(report.Domain, want)
+			if err != nil {
+				return err
+			}
+
+			want.ID = r.ID
+			report.Created = append(report.Created, want)
+			continue
+		}
+
+		want.ID = existing[i].ID
+		existing = append(existing[:i], existing[i+1:]...)
+
+		if _, err := cc.UpdateRecordByDomain(report.Domain, want); err != nil {
+			return err
+		}
+
+		report.Updated = append(report.Updated, want)
+	}
+
+	for _, r := range existing {
+		if unmanagedRecordTypes[r.RecordType] {
+			continue
+		}
+
+		if err := cc.DeleteRecordByDomain(report.Domain, r.ID); err != nil {
+			return err
+		}
+
+		report.Deleted = append(report.Deleted, r)
+	}
+
+	return nil
+}
+
+// EnsureDomain makes sure a domain exists and converges its records with SyncRecords. A missing domain is created with baseIP, and an A record for "@" pointing to baseIP is added to records unless they already have one.
+func (c *Client) EnsureDomain(ctx context.Context, name string, baseIP net.IP, records []DomainRecord) (*SyncReport, error) {
+	cc := c.WithContext(ctx)
+
+	if baseIP != nil {
+		hasApex := false
+		for _, r := range records {
+			if r.RecordType == "A" && r.Name == "@" {
+				hasApex = true
+				break
+			}
+		}
+
+		if !hasApex {
+			records = append([]DomainRecord{{RecordType: "A", Name: "@", Data: baseIP.String()}}, records...)
+		}
+	}
+
+	domains, err := cc.GetAllDomains()
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	for _, d := range domains {
+		if d.Name == name {
+			found = true
+			break
+		}
+	}
+
+	report := &SyncReport{Domain: name, DomainCreated: !found}
+	if report.DomainCreated {
+		if _, err := cc.CreateDomain(name, baseIP); err != nil {
+			return nil, err
+		}
+
+		if c.DryRun() {
+			// The domain doesn't exist, so all records would be created
+			return report, c.syncRecords(ctx, report, nil, records)
+		}
+	}
+
+	existing, err := cc.GetAllRecordsByDomain(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, c.syncRecords(ctx, report, existing, records)
+}
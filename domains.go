@@ -3,6 +3,8 @@ package godo
 import (
 	"fmt"
 	"net"
+	"net/url"
+	"strconv"
 )
 
 // Domain maps to the domain(s) field in the response
@@ -23,14 +25,39 @@ type PartialDomain struct {
 
 // DomainRecord maps to the domain record response
 type DomainRecord struct {
-	ID         int    `json:"id"`
-	DomainID   int    `json:"domain_id"`
+	ID         int    `json:"id,omitempty"`
+	DomainID   int    `json:"domain_id,omitempty"`
 	RecordType string `json:"record_type"`
 	Name       string `json:"name"`
 	Data       string `json:"data"`
-	Priority   int    `json:"priority"`
-	Port       int    `json:"port"`
-	Weight     int    `json:"weight"`
+	Priority   int    `json:"priority,omitempty"`
+	Port       int    `json:"port,omitempty"`
+	Weight     int    `json:"weight,omitempty"`
+}
+
+// params returns the query parameters for creating or updating the record
+func (r DomainRecord) params() url.Values {
+	params := url.Values{}
+	params.Set("record_type", r.RecordType)
+	params.Set("data", r.Data)
+
+	if r.Name != "" {
+		params.Set("name", r.Name)
+	}
+
+	if r.Priority != 0 {
+		params.Set("priority", strconv.Itoa(r.Priority))
+	}
+
+	if r.Port != 0 {
+		params.Set("port", strconv.Itoa(r.Port))
+	}
+
+	if r.Weight != 0 {
+		params.Set("weight", strconv.Itoa(r.Weight))
+	}
+
+	return params
 }
 
 // CreateDomain creates a new domain
@@ -44,83 +71,37 @@ func (c *Client) CreateDomain(name string, IP net.IP) (*PartialDomain, error) {
 		return nil, fmt.Errorf("IP address must be set and valid")
 	}
 
-	s := fmt.Sprintf("/domains/new?name=%s&ip_address=%s", name, IP)
+	params := url.Values{}
+	params.Set("name", name)
+	params.Set("ip_address", IP.String())
 
-	var DOResp struct {
-		Status  Status        `json:"status"`
-		Domain  PartialDomain `json:"domain"`
-		Message string        `json:"message"`
-	}
-
-	err := c.doGet(s, &DOResp)
+	v, err := call[PartialDomain](c, "/domains/new", params, "domain", "create domain")
 	if err != nil {
 		return nil, err
 	}
 
-	if DOResp.Status == StatusError {
-		return nil, fmt.Errorf("could not create domain: %v", DOResp.Message)
-	}
-
-	return &DOResp.Domain, nil
+	return &v, nil
 }
 
 // DeleteDomainByID returns a domain by its ID
 func (c *Client) DeleteDomainByID(ID interface{}) error {
-	var DOResp struct {
-		Status  Status `json:"status"`
-		Message string `json:"message"`
-	}
-
-	err := c.doGet(fmt.Sprintf("/domains/%v/destroy", ID), &DOResp)
-	if err != nil {
-		return err
-	}
-
-	if DOResp.Status == StatusError {
-		return fmt.Errorf("could not delete domain with ID %v: %v", ID, DOResp.Message)
-	}
-
-	return nil
+	_, err := call[struct{}](c, fmt.Sprintf("/domains/%s/destroy", pathID(ID)), nil, "", "delete domain with ID %v", ID)
+	return err
 }
 
 // GetAllDomains returns all current domain
 func (c *Client) GetAllDomains() ([]Domain, error) {
-	var DOResp struct {
-		Status  Status   `json:"status"`
-		Domains []Domain `json:"domains"`
-		Message string   `json:"message"`
-	}
-
-	err := c.doGet("/domains", &DOResp)
-	if err != nil {
-		return nil, err
-	}
-
-	if DOResp.Status == StatusError {
-		return nil, fmt.Errorf("could not get domains: %v", DOResp.Message)
-	}
-
-	return DOResp.Domains, nil
+	return list[Domain](c, "/domains", nil, "domains", "get domains")
 }
 
 // GetDomainByID returns a domain by its ID
 func (c *Client) GetDomainByID(ID int) (*Domain, error) {
-	var DOResp struct {
-		Status  Status `json:"status"`
-		Domain  Domain `json:"domain"`
-		Message string `json:"message"`
-	}
-
-	err := c.doGet(fmt.Sprintf("/domains/%d", ID), &DOResp)
+	v, err := call[Domain](c, fmt.Sprintf("/domains/%d", ID), nil, "domain", "get domain with ID %d", ID)
 	if err != nil {
 		return nil, err
 	}
 
-	if DOResp.Status == StatusError {
-		return nil, fmt.Errorf("could not get domain with ID %d: %v", ID, DOResp.Message)
-	}
-
-	return &DOResp.Domain, nil
+	return &v, nil
 }
 
 // CreateDomainRecord creates a record for a domain by ID, if sucessfully it will returns a new DomainRecord
@@ -134,80 +115,27 @@ func (c *Client) CreateDomainRecord(ID interface{}, r DomainRecord) (*DomainReco
 		return nil, fmt.Errorf("data value must be set")
 	}
 
-	s := fmt.Sprintf("/domains/%v/records/new?record_type=%s&data=%s", ID, r.RecordType, r.Data)
-
-	if r.Name != "" {
-		s += fmt.Sprintf("&name=%s", r.Name)
-	}
-
-	if r.Priority != 0 {
-		s += fmt.Sprintf("&priority=%d", r.Priority)
-	}
-
-	if r.Port != 0 {
-		s += fmt.Sprintf("&port=%d", r.Port)
-	}
-
-	if r.Weight != 0 {
-		s += fmt.Sprintf("&weight=%d", r.Weight)
-	}
-
-	var DOResp struct {
-		Status  Status       `json:"status"`
-		Record  DomainRecord `json:"record"`
-		Message string       `json:"message"`
-	}
-
-	err := c.doGet(s, &DOResp)
+	v, err := call[DomainRecord](c, fmt.Sprintf("/domains/%s/records/new", pathID(ID)), r.params(), "record", "create record for domain %v", ID)
 	if err != nil {
 		return nil, err
 	}
 
-	if DOResp.Status == StatusError {
-		return nil, fmt.Errorf("could not create record for domain %v: %v", ID, DOResp.Message)
-	}
-
-	return &DOResp.Record, nil
+	return &v, nil
 }
 
 // GetAllRecordsByDomain returns all current domain records for a specific domain. The domainID can be integer or string
 func (c *Client) GetAllRecordsByDomain(domainID interface{}) ([]DomainRecord, error) {
-	var DOResp struct {
-		Status  Status         `json:"status"`
-		Records []DomainRecord `json:"records"`
-		Message string         `json:"message"`
-	}
-
-	err := c.doGet(fmt.Sprintf("/domains/%v/records", domainID), &DOResp)
-	if err != nil {
-		return nil, err
-	}
-
-	if DOResp.Status == StatusError {
-		return nil, fmt.Errorf("could not get records for domain %v: %v", domainID, DOResp.Message)
-	}
-
-	return DOResp.Records, nil
+	return list[DomainRecord](c, fmt.Sprintf("/domains/%s/records", pathID(domainID)), nil, "records", "get records for domain %v", domainID)
 }
 
 // GetRecordByDomain return a domain record by domain ID and record ID. domainID can be integer or string
 func (c *Client) GetRecordByDomain(domainID interface{}, ID int) (*DomainRecord, error) {
-	var DOResp struct {
-		Status  Status       `json:"status"`
-		Record  DomainRecord `json:"record"`
-		Message string       `json:"message"`
-	}
-
-	err := c.doGet(fmt.Sprintf("/domains/%v/records/%d", domainID, ID), &DOResp)
+	v, err := call[DomainRecord](c, fmt.Sprintf("/domains/%s/records/%d", pathID(domainID), ID), nil, "record", "get record for domain %v with ID %d", domainID, ID)
 	if err != nil {
 		return nil, err
 	}
 
-	if DOResp.Status == StatusError {
-		return nil, fmt.Errorf("could not get record for domain %v with ID %d: %v", domainID, ID, DOResp.Message)
-	}
-
-	return &DOResp.Record, nil
+	return &v, nil
 }
 
 // UpdateRecordByDomain updates a domain record by domain ID and record ID. domainID can be integer or string
@@ -225,57 +153,16 @@ func (c *Client) UpdateRecordByDomain(domainID interface{}, r DomainRecord) (*Do
 		return nil, fmt.Errorf("data value must be set")
 	}
 
-	s := fmt.Sprintf("/domains/%v/records/new?record_type=%s&data=%s", domainID, r.ID, r.RecordType, r.Data)
-
-	if r.Name != "" {
-		s += fmt.Sprintf("&name=%s", r.Name)
-	}
-
-	if r.Priority != 0 {
-		s += fmt.Sprintf("&priority=%d", r.Priority)
-	}
-
-	if r.Port != 0 {
-		s += fmt.Sprintf("&port=%d", r.Port)
-	}
-
-	if r.Weight != 0 {
-		s += fmt.Sprintf("&weight=%d", r.Weight)
-	}
-
-	var DOResp struct {
-		Status  Status       `json:"status"`
-		Record  DomainRecord `json:"record"`
-		Message string       `json:"message"`
-	}
-
-	err := c.doGet(s, &DOResp)
+	v, err := call[DomainRecord](c, fmt.Sprintf("/domains/%s/records/%d/edit", pathID(domainID), r.ID), r.params(), "record", "update record %d for domain %v", r.ID, domainID)
 	if err != nil {
 		return nil, err
 	}
 
-	if DOResp.Status == StatusError {
-		return nil, fmt.Errorf("could not create record %d for domain %v: %v", r.ID, domainID, DOResp.Message)
-	}
-
-	return &DOResp.Record, nil
+	return &v, nil
 }
 
 // DeleteRecordByDomain delete a domain record
 func (c *Client) DeleteRecordByDomain(domainID interface{}, ID int) error {
-	var DOResp struct {
-		Status  Status `json:"status"`
-		Message string `json:"message"`
-	}
-
-	err := c.doGet(fmt.Sprintf("/domains/%v/records/%d/destroy", domainID, ID), &DOResp)
-	if err != nil {
-		return err
-	}
-
-	if DOResp.Status == StatusError {
-		return fmt.Errorf("could not delete record %d for domain with ID %v: %v", domainID, ID, DOResp.Message)
-	}
-
-	return nil
+	_, err := call[struct{}](c, fmt.Sprintf("/domains/%s/records/%d/destroy", pathID(domainID), ID), nil, "", "delete record %d for domain with ID %v", ID, domainID)
+	return err
 }
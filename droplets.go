@@ -1,7 +1,13 @@
 package godo
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -13,39 +19,182 @@ const (
 
 // Droplet maps to the droplet(s) field in the response
 type Droplet struct {
-	ID               int       `json:"id"`
-	Name             string    `json:"name"`
-	ImageID          int       `json:"image_id"`
-	SizeID           int       `json:"size_id"`
-	RegionID         int       `json:"region_id"`
-	BackupsActive    bool      `json:"backups_active"`
-	IPAdress         string    `json:"ip_address"`
-	PrivateIPAddress string    `json:"private_ip_address"`
-	Locked           bool      `json:"locked"`
-	Status           string    `json:"status"`
-	CreatedAt        time.Time `json:"created_at"`
-}
-
-// NewDroplet maps to the data that is required to create a new droplet
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	ImageID       int    `json:"image_id"`
+	SizeID        int    `json:"size_id"`
+	RegionID      int    `json:"region_id"`
+	BackupsActive bool   `json:"backups_active"`
+	// IPAdress and PrivateIPAddress are the first public and private IPv4 addresses of Networks
+	IPAdress         string        `json:"ip_address"`
+	PrivateIPAddress string        `json:"private_ip_address"`
+	Networks         Networks      `json:"networks"`
+	Locked           bool          `json:"locked"`
+	Status           DropletStatus `json:"status"`
+	CreatedAt        time.Time     `json:"created_at"`
+}
+
+// DropletStatus is the power and lifecycle state of a droplet
+type DropletStatus string
+
+const (
+	// DropletStatusNew is the status of a droplet which is being created
+	DropletStatusNew DropletStatus = "new"
+	// DropletStatusActive is the status of a running droplet
+	DropletStatusActive DropletStatus = "active"
+	// DropletStatusOff is the status of a powered off droplet
+	DropletStatusOff DropletStatus = "off"
+	// DropletStatusArchive is the status of a destroyed droplet kept for a while
+	DropletStatusArchive DropletStatus = "archive"
+)
+
+// IsActive reports whether the droplet is running
+func (s DropletStatus) IsActive() bool {
+	return s == DropletStatusActive
+}
+
+// IsOff reports whether the droplet is powered off
+func (s DropletStatus) IsOff() bool {
+	return s == DropletStatusOff
+}
+
+// IsNew reports whether the droplet is still being created
+func (s DropletStatus) IsNew() bool {
+	return s == DropletStatusNew
+}
+
+// IsArchived reports whether the droplet has been destroyed
+func (s DropletStatus) IsArchived() bool {
+	return s == DropletStatusArchive
+}
+
+// Is reports whether the droplet has the given status and no pending event locks it, i.e. it is ready for the next action
+func (d Droplet) Is(status DropletStatus) bool {
+	return d.Status == status && !d.Locked
+}
+
+// Ready reports whether the droplet is running and not locked by a pending event
+func (d Droplet) Ready() bool {
+	return d.Is(DropletStatusActive)
+}
+
+// UnmarshalJSON decodes a droplet, filling Networks from the flat addresses when the response has no networks and the flat addresses from Networks when it has no flat ones
+func (d *Droplet) UnmarshalJSON(data []byte) error {
+	type droplet Droplet
+	if err := json.Unmarshal(data, (*droplet)(d)); err != nil {
+		return err
+	}
+
+	if len(d.Networks.V4) == 0 {
+		if d.IPAdress != "" {
+			d.Networks.V4 = append(d.Networks.V4, NetworkInterface{IPAddress: d.IPAdress, Type: NetworkPublic})
+		}
+		if d.PrivateIPAddress != "" {
+			d.Networks.V4 = append(d.Networks.V4, NetworkInterface{IPAddress: d.PrivateIPAddress, Type: NetworkPrivate})
+		}
+	}
+
+	if d.IPAdress == "" {
+		d.IPAdress = d.Networks.PublicIPv4()
+	}
+	if d.PrivateIPAddress == "" {
+		d.PrivateIPAddress = d.Networks.PrivateIPv4()
+	}
+
+	return nil
+}
+
+// Equal reports whether two droplets have the same fields. Droplets can't be compared with == since Networks holds slices.
+func (d Droplet) Equal(o Droplet) bool {
+	return reflect.DeepEqual(d, o)
+}
+
+// Network types of a NetworkInterface
+const (
+	NetworkPublic  = "public"
+	NetworkPrivate = "private"
+)
+
+// NetworkInterface is an address of a droplet on a network
+type NetworkInterface struct {
+	IPAddress string `json:"ip_address"`
+	Netmask   string `json:"netmask,omitempty"`
+	Gateway   string `json:"gateway,omitempty"`
+	// Type is NetworkPublic or NetworkPrivate
+	Type string `json:"type"`
+}
+
+// Networks are the addresses of a droplet by IP version
+type Networks struct {
+	V4 []NetworkInterface `json:"v4,omitempty"`
+	V6 []NetworkInterface `json:"v6,omitempty"`
+}
+
+// PublicIPv4 returns the first public IPv4 address, or an empty string if there is none
+func (n Networks) PublicIPv4() string {
+	return firstAddress(n.V4, NetworkPublic)
+}
+
+// PrivateIPv4 returns the first private IPv4 address, or an empty string if there is none
+func (n Networks) PrivateIPv4() string {
+	return firstAddress(n.V4, NetworkPrivate)
+}
+
+// PublicIPv6 returns the first public IPv6 address, or an empty string if there is none
+func (n Networks) PublicIPv6() string {
+	return firstAddress(n.V6, NetworkPublic)
+}
+
+func firstAddress(ifaces []NetworkInterface, typ string) string {
+	for _, i := range ifaces {
+		if i.Type == typ {
+			return i.IPAddress
+		}
+	}
+
+	return ""
+}
+
+// NewDroplet maps to the data that is required to create a new droplet. It can be read from and written to files with the codecs of this package.
 type NewDroplet struct {
 	// Name is required
-	Name string
+	Name string `json:"name"`
 
 	// Either SizeID or SizeSlug must be set
-	SizeID   int
-	SizeSlug string
+	SizeID   int    `json:"size_id,omitempty"`
+	SizeSlug string `json:"size_slug,omitempty"`
 
 	// Either IamgeID or ImageSlug must be set
-	ImageID   int
-	ImageSlug string
+	ImageID   int    `json:"image_id,omitempty"`
+	ImageSlug string `json:"image_slug,omitempty"`
 
 	// Either RegionID or RegionSlug must be set
-	RegionID   int
-	RegionSlug string
+	RegionID   int    `json:"region_id,omitempty"`
+	RegionSlug string `json:"region_slug,omitempty"`
+
+	SSHKeyIDs         []string `json:"ssh_key_ids,omitempty"`
+	PrivateNetworking bool     `json:"private_networking,omitempty"`
+	BackupsEnabled    bool     `json:"backups_enabled,omitempty"`
+
+	// Identity is encoded in the name with WithIdentity if set, so the droplet can be found by GetDropletByIdentity
+	Identity string `json:"identity,omitempty"`
 
-	SSHKeyIDs         []string
-	PrivateNetworking bool
-	BackupsEnabled    bool
+	// ExpiresAt is encoded in the name with WithExpiry if set, so ReapExpired destroys the droplet after it
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+}
+
+// fullName returns the name of the droplet with the identity and expiry encoded
+func (n NewDroplet) fullName() string {
+	name := n.Name
+	if n.Identity != "" {
+		name = WithIdentity(name, n.Identity)
+	}
+
+	if !n.ExpiresAt.IsZero() {
+		name = WithExpiry(name, n.ExpiresAt)
+	}
+
+	return name
 }
 
 // PartialDroplet maps to the partial droplet data in the response when a new droplet is created successfully
@@ -55,9 +204,13 @@ type PartialDroplet struct {
 	ImageID int    `json:"image_id"`
 	SizeID  int    `json:"size_id"`
 	EventID int    `json:"event_id"`
+
+	// RegionID and RegionSlug identify the region the droplet was created in, which may be a fallback region, see WithRegionFallback
+	RegionID   int    `json:"region_id"`
+	RegionSlug string `json:"-"`
 }
 
-// CreateDroplet creates a new droplet
+// CreateDroplet creates a new droplet. If the region lacks capacity and the client has fallback regions, the droplet is created in the first of them which has capacity.
 func (c *Client) CreateDroplet(n NewDroplet) (*PartialDroplet, error) {
 	// Validate
 	if n.SizeID == 0 && n.SizeSlug == "" {
@@ -72,349 +225,307 @@ func (c *Client) CreateDroplet(n NewDroplet) (*PartialDroplet, error) {
 		return nil, fmt.Errorf("region ID or slug must be set")
 	}
 
-	s := fmt.Sprintf("/droplets/new?name=%s", n.Name)
+	if n.Identity != "" {
+		if err := validIdentity(n.Identity); err != nil {
+			return nil, err
+		}
+	}
+
+	attempts := []NewDroplet{n}
+	for _, slug := range c.RegionFallback {
+		if slug == n.RegionSlug {
+			continue
+		}
+
+		alt := n
+		alt.RegionID = 0
+		alt.RegionSlug = slug
+		attempts = append(attempts, alt)
+	}
+
+	var err error
+	for _, a := range attempts {
+		var pd *PartialDroplet
+		if pd, err = c.createDroplet(a); err == nil || !isCapacityError(err) {
+			return pd, err
+		}
+	}
+
+	return nil, err
+}
+
+// createDroplet creates a droplet from a validated NewDroplet in its region
+func (c *Client) createDroplet(n NewDroplet) (*PartialDroplet, error) {
+	var requested []Feature
+	if n.PrivateNetworking {
+		requested = append(requested, FeaturePrivateNetworking)
+	}
+	if n.BackupsEnabled {
+		requested = append(requested, FeatureBackups)
+	}
+
+	region := n.RegionSlug
+	if n.RegionID != 0 {
+		region = strconv.Itoa(n.RegionID)
+	}
+
+	for _, f := range requested {
+		if err := c.features.check(f, region); err != nil {
+			return nil, err
+		}
+	}
+
+	params := url.Values{}
+	params.Set("name", n.fullName())
 
 	if n.SizeID != 0 {
-		s += fmt.Sprintf("&size_id=%d", n.SizeID)
+		params.Set("size_id", strconv.Itoa(n.SizeID))
 	} else {
-		s += "&size_slug=" + n.SizeSlug
+		params.Set("size_slug", n.SizeSlug)
 	}
 
 	if n.ImageID != 0 {
-		s += fmt.Sprintf("&image_id=%d", n.ImageID)
+		params.Set("image_id", strconv.Itoa(n.ImageID))
 	} else {
-		s += "&image_slug=" + n.ImageSlug
+		params.Set("image_slug", n.ImageSlug)
 	}
 
 	if n.RegionID != 0 {
-		s += fmt.Sprintf("&region_id=%d", n.RegionID)
+		params.Set("region_id", strconv.Itoa(n.RegionID))
 	} else {
-		s += "&region_slug=" + n.RegionSlug
+		params.Set("region_slug", n.RegionSlug)
 	}
 
 	if len(n.SSHKeyIDs) > 0 {
-		s += "&ssh_key_ids=" + strings.Join(n.SSHKeyIDs, ",")
+		params.Set("ssh_key_ids", strings.Join(n.SSHKeyIDs, ","))
 	}
 
 	if n.PrivateNetworking {
-		s += "&private_networking=true"
+		params.Set("private_networking", "true")
 	}
 
 	if n.BackupsEnabled {
-		s += "&backups_enabled=true"
-	}
-
-	var DOResp struct {
-		Status  Status         `json:"status"`
-		Droplet PartialDroplet `json:"droplet"`
-		Message string         `json:"message"`
+		params.Set("backups_enabled", "true")
 	}
 
-	err := c.doGet(s, &DOResp)
+	v, err := call[PartialDroplet](c, "/droplets/new", params, "droplet", "create droplet")
 	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			if err := c.features.detect(apiErr.Message, region, requested...); err != nil {
+				return nil, err
+			}
+		}
+
 		return nil, err
 	}
 
-	if DOResp.Status == StatusError {
-		return nil, fmt.Errorf("could not create droplet: %v", DOResp.Message)
+	if v.RegionID == 0 {
+		v.RegionID = n.RegionID
 	}
+	v.RegionSlug = n.RegionSlug
+
+	c.boots.created(v.ID, n)
 
-	return &DOResp.Droplet, nil
+	return &v, nil
 }
 
 // DeleteDropletByID returns a domain by its ID. Returns an event ID on success
 func (c *Client) DeleteDropletByID(ID int) (int, error) {
-	var DOResp struct {
-		Status  Status `json:"status"`
-		EventID int    `json:"event_id"`
-		Message string `json:"message"`
-	}
-
-	err := c.doGet(fmt.Sprintf("/droplets/%d/destroy", ID), &DOResp)
-	if err != nil {
-		return 0, err
-	}
-
-	if DOResp.Status == StatusError {
-		return 0, fmt.Errorf("could not delete droplet with ID %d: %v", ID, DOResp.Message)
-	}
-
-	return DOResp.EventID, nil
+	return call[int](c, fmt.Sprintf("/droplets/%d/destroy", ID), nil, "event_id", "delete droplet with ID %d", ID)
 }
 
 // GetAllDroplets returns all active droplets
 func (c *Client) GetAllDroplets() ([]Droplet, error) {
-	var DOResp struct {
-		Status   Status    `json:"status"`
-		Droplets []Droplet `json:"droplets"`
-		Message  string    `json:"message"`
-	}
-
-	err := c.doGet("/droplets", &DOResp)
-	if err != nil {
-		return nil, err
-	}
-
-	if DOResp.Status == StatusError {
-		return nil, fmt.Errorf("could not get droplets: %v", DOResp.Message)
-	}
+	return list[Droplet](c, "/droplets", nil, "droplets", "get droplets")
+}
 
-	return DOResp.Droplets, nil
+// GetAllDropletsConcurrently returns all droplets like GetAllDroplets, but fetches their pages concurrently with the client's concurrency, which is much faster for accounts with many droplets
+func (c *Client) GetAllDropletsConcurrently(ctx context.Context) ([]Droplet, error) {
+	return listConcurrent[Droplet](ctx, c, "/droplets", nil, "droplets", "get droplets")
 }
 
 // GetDropletByID returns a domain by its ID
 func (c *Client) GetDropletByID(ID int) (*Droplet, error) {
-	var DOResp struct {
-		Status  Status  `json:"status"`
-		Droplet Droplet `json:"droplet"`
-		Message string  `json:"message"`
-	}
-
-	err := c.doGet(fmt.Sprintf("/droplets/%d", ID), &DOResp)
+	v, err := call[Droplet](c, fmt.Sprintf("/droplets/%d", ID), nil, "droplet", "get droplet with ID %d", ID)
 	if err != nil {
 		return nil, err
 	}
 
-	if DOResp.Status == StatusError {
-		return nil, fmt.Errorf("could not get droplet with ID %d: %v", ID, DOResp.Message)
-	}
-
-	return &DOResp.Droplet, nil
+	return &v, nil
 }
 
-// RebootDroplet reboot a droplet. This is the preferred method to use if a server is not responding. Returns an event ID on success.
-func (c *Client) RebootDroplet(ID int) (int, error) {
-	var DOResp struct {
-		Status  Status `json:"status"`
-		EventID int    `json:"event_id"`
-		Message string `json:"message"`
-	}
-
-	err := c.doGet(fmt.Sprintf("/droplets/%d/reboot", ID), &DOResp)
-	if err != nil {
-		return 0, err
-	}
+// AmbiguousNameError is returned when several droplets have the name looked up. It matches ErrAmbiguousName with errors.Is.
+type AmbiguousNameError struct {
+	Name string
+	IDs  []int
+}
 
-	if DOResp.Status == StatusError {
-		return 0, fmt.Errorf("could not reboot droplet with ID %d: %v", ID, DOResp.Message)
-	}
+func (e *AmbiguousNameError) Error() string {
+	return fmt.Sprintf("%d droplets are named %q", len(e.IDs), e.Name)
+}
 
-	return DOResp.EventID, nil
+// Is reports whether target is ErrAmbiguousName
+func (e *AmbiguousNameError) Is(target error) bool {
+	return target == ErrAmbiguousName
 }
 
-// PowerCycleDroplet power cycle a droplet. This will turn off the droplet and then turn it back on. Returns an event ID on success.
-func (c *Client) PowerCycleDroplet(ID int) (int, error) {
-	var DOResp struct {
-		Status  Status `json:"status"`
-		EventID int    `json:"event_id"`
-		Message string `json:"message"`
+// GetDropletByName returns the droplet with exactly the given name. The error matches ErrNotFound if there is none, or is an AmbiguousNameError if there are several.
+func (c *Client) GetDropletByName(name string) (*Droplet, error) {
+	droplets, err := c.GetAllDroplets()
+	if err != nil {
+		return nil, err
 	}
 
-	err := c.doGet(fmt.Sprintf("/droplets/%d/power_cycle", ID), &DOResp)
+	d, err := dropletNamed(droplets, name)
 	if err != nil {
-		return 0, err
+		return nil, fmt.Errorf("could not get droplet named %q: %w", name, err)
 	}
 
-	if DOResp.Status == StatusError {
-		return 0, fmt.Errorf("could not reboot droplet with ID %d: %v", ID, DOResp.Message)
+	if d == nil {
+		return nil, fmt.Errorf("could not get droplet named %q: %w", name, ErrNotFound)
 	}
 
-	return DOResp.EventID, nil
+	return d, nil
 }
 
-// ShutDownDroplet shut down a running droplet. This will turn off the droplet but it will remain in client's account. Returns an event ID on success.
-func (c *Client) ShutDownDroplet(ID int) (int, error) {
-	var DOResp struct {
-		Status  Status `json:"status"`
-		EventID int    `json:"event_id"`
-		Message string `json:"message"`
-	}
+// dropletNamed returns the droplet with the given name, or nil if there is none
+func dropletNamed(droplets []Droplet, name string) (*Droplet, error) {
+	return dropletMatching(droplets, name, func(n string) bool { return n == name })
+}
 
-	err := c.doGet(fmt.Sprintf("/droplets/%d/shutdown", ID), &DOResp)
-	if err != nil {
-		return 0, err
+// dropletMatching is like dropletNamed, but matches the names of droplets with match
+func dropletMatching(droplets []Droplet, name string, match func(string) bool) (*Droplet, error) {
+	var found *Droplet
+	var ids []int
+	for i, d := range droplets {
+		if match(d.Name) {
+			found = &droplets[i]
+			ids = append(ids, d.ID)
+		}
 	}
 
-	if DOResp.Status == StatusError {
-		return 0, fmt.Errorf("could not shut down droplet with ID %d: %v", ID, DOResp.Message)
+	if len(ids) > 1 {
+		return nil, &AmbiguousNameError{Name: name, IDs: ids}
 	}
 
-	return DOResp.EventID, nil
+	return found, nil
 }
 
-// PowerOffDroplet power off a running droplet. The droplet will remain in client's account. Returns an event ID on success.
-func (c *Client) PowerOffDroplet(ID int) (int, error) {
-	var DOResp struct {
-		Status  Status `json:"status"`
-		EventID int    `json:"event_id"`
-		Message string `json:"message"`
-	}
+// RebootDroplet reboot a droplet. This is the preferred method to use if a server is not responding. Returns an event ID on success.
+func (c *Client) RebootDroplet(ID int) (int, error) {
+	return c.restartDroplet(ID, "reboot", "reboot")
+}
 
-	err := c.doGet(fmt.Sprintf("/droplets/%d/power_off", ID), &DOResp)
-	if err != nil {
-		return 0, err
-	}
+// PowerCycleDroplet power cycle a droplet. This will turn off the droplet and then turn it back on. Returns an event ID on success.
+func (c *Client) PowerCycleDroplet(ID int) (int, error) {
+	return c.restartDroplet(ID, "power_cycle", "power cycle")
+}
 
-	if DOResp.Status == StatusError {
-		return 0, fmt.Errorf("could not power off droplet with ID %d: %v", ID, DOResp.Message)
-	}
+// ShutDownDroplet shut down a running droplet. This will turn off the droplet but it will remain in client's account. Returns an event ID on success.
+func (c *Client) ShutDownDroplet(ID int) (int, error) {
+	return call[int](c, fmt.Sprintf("/droplets/%d/shutdown", ID), nil, "event_id", "shut down droplet with ID %d", ID)
+}
 
-	return DOResp.EventID, nil
+// PowerOffDroplet power off a running droplet. The droplet will remain in client's account. Returns an event ID on success.
+func (c *Client) PowerOffDroplet(ID int) (int, error) {
+	return call[int](c, fmt.Sprintf("/droplets/%d/power_off", ID), nil, "event_id", "power off droplet with ID %d", ID)
 }
 
 // PowerOnDroplet power on a powered off droplet. Returns an event ID on success.
 func (c *Client) PowerOnDroplet(ID int) (int, error) {
-	var DOResp struct {
-		Status  Status `json:"status"`
-		EventID int    `json:"event_id"`
-		Message string `json:"message"`
-	}
-
-	err := c.doGet(fmt.Sprintf("/droplets/%d/power_on", ID), &DOResp)
-	if err != nil {
-		return 0, err
-	}
-
-	if DOResp.Status == StatusError {
-		return 0, fmt.Errorf("could not power on droplet with ID %d: %v", ID, DOResp.Message)
-	}
-
-	return DOResp.EventID, nil
+	return call[int](c, fmt.Sprintf("/droplets/%d/power_on", ID), nil, "event_id", "power on droplet with ID %d", ID)
 }
 
 // ResetRootPassDroplet reset root's password for a droplet. Please be aware that this will reboot the droplet to allow resetting the password. Returns an event ID on success.
 func (c *Client) ResetRootPassDroplet(ID int) (int, error) {
-	var DOResp struct {
-		Status  Status `json:"status"`
-		EventID int    `json:"event_id"`
-		Message string `json:"message"`
-	}
-
-	err := c.doGet(fmt.Sprintf("/droplets/%d/password_reset", ID), &DOResp)
-	if err != nil {
-		return 0, err
-	}
-
-	if DOResp.Status == StatusError {
-		return 0, fmt.Errorf("could not reset root's password for droplet with ID %d: %v", ID, DOResp.Message)
-	}
+	return call[int](c, fmt.Sprintf("/droplets/%d/password_reset", ID), nil, "event_id", "reset root's password for droplet with ID %d", ID)
+}
 
-	return DOResp.EventID, nil
+// ResizeOptions configures a resize. The zero value is a flexible resize, which only changes CPU and memory and can be reverted by resizing to a smaller size again. A permanent resize also grows the disk, after which the droplet can't be resized to a size with a smaller disk.
+type ResizeOptions struct {
+	// Disk resizes the disk too, making the resize permanent
+	Disk bool
 }
 
-// ResizeDroplet resizes a droplet to a different size. The size param can be either string or integer. Returns an event ID on success.
+// ResizeDroplet resizes a droplet to a different size with a flexible resize, see ResizeDropletWithOptions. The size param can be either string or integer. Returns an event ID on success.
 func (c *Client) ResizeDroplet(ID int, size interface{}) (int, error) {
-	var DOResp struct {
-		Status  Status `json:"status"`
-		EventID int    `json:"event_id"`
-		Message string `json:"message"`
-	}
+	return c.ResizeDropletWithOptions(ID, size, ResizeOptions{})
+}
 
-	s := fmt.Sprintf("/droplets/%d/resize", ID)
+// ResizeDropletWithOptions resizes a droplet to a different size, either flexibly or permanently as set by opts. The size param can be either string or integer. Returns an event ID on success.
+func (c *Client) ResizeDropletWithOptions(ID int, size interface{}, opts ResizeOptions) (int, error) {
+	params := url.Values{}
 
-	switch size.(type) {
+	switch size := size.(type) {
 	case string:
-		s += fmt.Sprintf("&size_slug=%s", size)
+		params.Set("size_slug", size)
 	case int:
-		s += fmt.Sprintf("&size_id=%d", size)
+		params.Set("size_id", strconv.Itoa(size))
 	default:
 		return 0, fmt.Errorf("size must be either a string or integer")
 	}
 
-	err := c.doGet(s, &DOResp)
-	if err != nil {
-		return 0, err
-	}
-
-	if DOResp.Status == StatusError {
-		return 0, fmt.Errorf("could not resize the droplet with ID %d: %v", ID, DOResp.Message)
+	if opts.Disk {
+		params.Set("disk", "true")
 	}
 
-	return DOResp.EventID, nil
+	return call[int](c, fmt.Sprintf("/droplets/%d/resize", ID), params, "event_id", "resize the droplet with ID %d", ID)
 }
 
 // TakeSnapshotOnDroplet takes a snapshot of the droplet once it has been powered off, which can later be restored or used to create a new droplet from the same image. Please be aware this may cause a reboot. If name is an empty string, it will default to date/time. Returns an event ID on success.
 func (c *Client) TakeSnapshotOnDroplet(ID int, name string) (int, error) {
-	var DOResp struct {
-		Status  Status `json:"status"`
-		EventID int    `json:"event_id"`
-		Message string `json:"message"`
-	}
-
-	s := fmt.Sprintf("/droplets/%d/snapshot", ID)
+	params := url.Values{}
 
 	if name != "" {
-		s += fmt.Sprintf("&name=%s", name)
-	}
-
-	err := c.doGet(s, &DOResp)
-	if err != nil {
-		return 0, err
-	}
-
-	if DOResp.Status == StatusError {
-		return 0, fmt.Errorf("could not take snapshot of droplet with ID %d: %v", ID, DOResp.Message)
+		params.Set("name", name)
 	}
 
-	return DOResp.EventID, nil
+	return call[int](c, fmt.Sprintf("/droplets/%d/snapshot", ID), params, "event_id", "take snapshot of droplet with ID %d", ID)
 }
 
 // RestoreDroplet restores a droplet from a previous image or snapshot. This will be a mirror copy of the image or snapshot to the droplet. Returns an event ID on success.
 func (c *Client) RestoreDroplet(ID, imageID int) (int, error) {
-	var DOResp struct {
-		Status  Status `json:"status"`
-		EventID int    `json:"event_id"`
-		Message string `json:"message"`
-	}
-
-	err := c.doGet(fmt.Sprintf("/droplets/%d/restore?image_id=%d", ID, imageID), &DOResp)
-	if err != nil {
-		return 0, err
-	}
-
-	if DOResp.Status == StatusError {
-		return 0, fmt.Errorf("could not restore droplet with ID %d: %v", ID, DOResp.Message)
-	}
-
-	return DOResp.EventID, nil
+	return call[int](c, fmt.Sprintf("/droplets/%d/restore", ID), url.Values{"image_id": {strconv.Itoa(imageID)}}, "event_id", "restore droplet with ID %d", ID)
 }
 
 // RebuildDroplet reinstalls a droplet with a default image. This is useful if you want to start again but retain the same IP address for your droplet. Returns an event ID on success.
 func (c *Client) RebuildDroplet(ID, imageID int) (int, error) {
-	var DOResp struct {
-		Status  Status `json:"status"`
-		EventID int    `json:"event_id"`
-		Message string `json:"message"`
-	}
-
-	err := c.doGet(fmt.Sprintf("/droplets/%d/rebuild?image_id=%d", ID, imageID), &DOResp)
-	if err != nil {
-		return 0, err
-	}
-
-	if DOResp.Status == StatusError {
-		return 0, fmt.Errorf("could not rebuild droplet with ID %d: %v", ID, DOResp.Message)
-	}
-
-	return DOResp.EventID, nil
+	return call[int](c, fmt.Sprintf("/droplets/%d/rebuild", ID), url.Values{"image_id": {strconv.Itoa(imageID)}}, "event_id", "rebuild droplet with ID %d", ID)
 }
 
 // RenameDroplet renames a droplet. Returns an event ID on success.
 func (c *Client) RenameDroplet(ID int, name string) (int, error) {
-	var DOResp struct {
-		Status  Status `json:"status"`
-		EventID int    `json:"event_id"`
-		Message string `json:"message"`
+	return call[int](c, fmt.Sprintf("/droplets/%d/rename", ID), url.Values{"name": {name}}, "event_id", "rename droplet with ID %d", ID)
+}
+
+// enableFeature enables a feature on a droplet with an action. If the API reports the feature as unavailable, an UnsupportedError is returned and later calls needing it fail without a request until the refusal expires.
+func (c *Client) enableFeature(ID int, f Feature, action string) (int, error) {
+	if err := c.features.check(f); err != nil {
+		return 0, err
 	}
 
-	err := c.doGet(fmt.Sprintf("/droplets/%d/rename?name=%s", ID, name), &DOResp)
+	v, err := call[int](c, fmt.Sprintf("/droplets/%d/%s", ID, action), nil, "event_id", "enable %s on droplet with ID %d", f, ID)
 	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			if err := c.features.detect(apiErr.Message, "", f); err != nil {
+				return 0, err
+			}
+		}
+
 		return 0, err
 	}
 
-	if DOResp.Status == StatusError {
-		return 0, fmt.Errorf("could not rename droplet with ID %d: %v", ID, DOResp.Message)
-	}
+	return v, nil
+}
+
+// EnableBackupsOnDroplet enables automatic backups of a droplet. Returns an event ID on success.
+func (c *Client) EnableBackupsOnDroplet(ID int) (int, error) {
+	return c.enableFeature(ID, FeatureBackups, "enable_backups")
+}
 
-	return DOResp.EventID, nil
+// DisableBackupsOnDroplet disables automatic backups of a droplet. Returns an event ID on success.
+func (c *Client) DisableBackupsOnDroplet(ID int) (int, error) {
+	return call[int](c, fmt.Sprintf("/droplets/%d/disable_backups", ID), nil, "event_id", "disable backups on droplet with ID %d", ID)
 }
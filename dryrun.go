@@ -0,0 +1,115 @@
+package godo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path"
+	"sync"
+)
+
+// mutatingActions are the last path segments of the GET endpoints which change resources
+var mutatingActions = map[string]bool{
+	"new":             true,
+	"edit":            true,
+	"destroy":         true,
+	"reboot":          true,
+	"power_cycle":     true,
+	"shutdown":        true,
+	"power_off":       true,
+	"power_on":        true,
+	"password_reset":  true,
+	"resize":          true,
+	"snapshot":        true,
+	"restore":         true,
+	"rebuild":         true,
+	"rename":          true,
+	"transfer":        true,
+	"enable_backups":  true,
+	"disable_backups": true,
+}
+
+// isMutating reports whether a call changes resources
+func isMutating(method, endpoint string) bool {
+	if method != http.MethodGet {
+		return true
+	}
+
+	return mutatingActions[path.Base(endpoint)]
+}
+
+// PlannedCall is a mutating call recorded in dry-run mode instead of being sent
+type PlannedCall struct {
+	Method   string
+	Endpoint string
+	Params   url.Values
+	// Body is the JSON body of the call, if any
+	Body json.RawMessage
+}
+
+// dryRunPlan records the mutating calls of a client in dry-run mode
+type dryRunPlan struct {
+	mu    sync.Mutex
+	calls []PlannedCall
+}
+
+func (p *dryRunPlan) record(method, endpoint string, params url.Values, payload []byte) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.calls = append(p.calls, PlannedCall{Method: method, Endpoint: endpoint, Params: params, Body: payload})
+}
+
+// WithDryRun puts the client in dry-run mode: mutating calls such as creating, destroying, resizing or renaming are not sent but recorded in the plan returned by Plan. They succeed with zero values, e.g. an event ID of 0, which the wait helpers treat as done. Read-only calls are sent as usual.
+func WithDryRun() Option {
+	return func(c *Client) {
+		c.dryRun = &dryRunPlan{}
+	}
+}
+
+// DryRun reports whether the client is in dry-run mode
+func (c *Client) DryRun() bool {
+	return c.dryRun != nil
+}
+
+// Plan returns the mutating calls recorded in dry-run mode, in the order they were made
+func (c *Client) Plan() []PlannedCall {
+	if c.dryRun == nil {
+		return nil
+	}
+
+	c.dryRun.mu.Lock()
+	defer c.dryRun.mu.Unlock()
+
+	return append([]PlannedCall(nil), c.dryRun.calls...)
+}
+
+// plannedResponse is the response to a planned call
+const plannedResponse = `{"status":"OK"}`
+
+// planCall records a mutating call in dry-run mode. It returns false if the call must be sent.
+func (c *Client) planCall(method, endpoint string, params url.Values, payload []byte) bool {
+	if c.dryRun == nil || !isMutating(method, endpoint) {
+		return false
+	}
+
+	c.dryRun.record(method, endpoint, params, payload)
+
+	return true
+}
+
+// dryRunCopy returns a copy of the client in dry-run mode with an empty plan. Planned operations are not reported as progress.
+func (c *Client) dryRunCopy() *Client {
+	cc := *c
+	cc.dryRun = &dryRunPlan{}
+	cc.progress = nil
+	return &cc
+}
+
+// Explain runs fn with a copy of the client in dry-run mode and returns the mutating calls it would make, in order, e.g. to review what EnsureDroplet or BuildImage would do. Read-only calls are sent, so the calls have their parameters resolved against the account. Resources which would be created have an ID of 0 in later calls. The plan so far is returned along with an error of fn.
+func (c *Client) Explain(ctx context.Context, fn func(ctx context.Context, c *Client) error) ([]PlannedCall, error) {
+	dry := c.dryRunCopy()
+	err := fn(ctx, dry)
+	return dry.Plan(), err
+}
@@ -0,0 +1,183 @@
+package godo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DriftPolicy decides what EnsureDroplet does with a droplet which differs from its spec
+type DriftPolicy int
+
+const (
+	// DriftReport leaves the droplet unchanged and returns a DriftError. It is the zero value, so nothing is changed unless asked for; see EnsureDroplet for the earlier default.
+	DriftReport DriftPolicy = iota
+	// DriftIgnore leaves the droplet unchanged and returns it
+	DriftIgnore
+	// DriftResize resizes the droplet to match the spec. Other drift is reported.
+	DriftResize
+	// DriftCorrect resizes the droplet and rebuilds it from the image of the spec, which wipes its disk. Images given by slug get a new ID whenever the distribution image is refreshed, so this rebuilds droplets created from them sooner or later.
+	DriftCorrect
+)
+
+// Drift is a difference between a droplet and its spec
+type Drift struct {
+	// Field is "size", "image" or "region"
+	Field string
+	Want  int
+	Have  int
+}
+
+// DriftError is returned by EnsureDroplet for a droplet which differs from its spec and can't or mustn't be corrected
+type DriftError struct {
+	ID     int
+	Name   string
+	Drifts []Drift
+}
+
+func (e *DriftError) Error() string {
+	fields := make([]string, len(e.Drifts))
+	for i, d := range e.Drifts {
+		fields[i] = fmt.Sprintf("%s is %d instead of %d", d.Field, d.Have, d.Want)
+	}
+
+	return fmt.Sprintf("droplet %q with ID %d has drifted: %s", e.Name, e.ID, strings.Join(fields, ", "))
+}
+
+// EnsureDroplet makes sure a droplet named as in spec exists and matches it. A missing droplet is created; a drifted one is handled according to policy; a compliant one is returned unchanged. Droplets are matched by name since the v1 API has no tags, ignoring the deadline encoded by WithExpiry, so the deadline of an existing droplet is left as it was when it was created. A different region can't be corrected, so it is always reported unless drift is ignored, and a different image is only corrected with DriftCorrect.
+//
+// The zero policy is DriftReport, which never changes an existing droplet. When EnsureDroplet was introduced the zero policy was DriftCorrect, which resized and rebuilt drifted droplets; callers which relied on that must now pass DriftCorrect explicitly, or DriftResize to only resize.
+func (c *Client) EnsureDroplet(ctx context.Context, spec NewDroplet, policy DriftPolicy) (*Droplet, error) {
+	ctx, cancel := c.operationContext(ctx)
+	defer cancel()
+
+	// The deadline is left out, as a spec built with ExpiresIn has a different one on every run
+	unexpiring := spec
+	unexpiring.ExpiresAt = time.Time{}
+	name := unexpiring.fullName()
+
+	droplets, err := c.WithContext(ctx).GetAllDroplets()
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := dropletMatching(droplets, name, func(n string) bool { return withoutExpiry(n) == name })
+	if err != nil {
+		return nil, fmt.Errorf("could not ensure droplet %q: %w", name, err)
+	}
+
+	if d == nil {
+		return c.createAndWait(ctx, spec)
+	}
+
+	if policy == DriftIgnore {
+		return d, nil
+	}
+
+	drifts, err := c.WithContext(ctx).dropletDrift(d, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(drifts) == 0 {
+		return d, nil
+	}
+
+	if policy == DriftReport {
+		return nil, &DriftError{ID: d.ID, Name: d.Name, Drifts: drifts}
+	}
+
+	for _, dr := range drifts {
+		if dr.Field == "region" || dr.Field == "image" && policy != DriftCorrect {
+			return nil, &DriftError{ID: d.ID, Name: d.Name, Drifts: drifts}
+		}
+	}
+
+	for _, dr := range drifts {
+		switch dr.Field {
+		case "image":
+			if err := c.waitForAction(ctx, func(c *Client) (int, error) { return c.RebuildDroplet(d.ID, dr.Want) }); err != nil {
+				return nil, err
+			}
+		case "size":
+			// Resizing requires the droplet to be powered off
+			if !d.Status.IsOff() {
+				if err := c.waitForAction(ctx, func(c *Client) (int, error) { return c.PowerOffDroplet(d.ID) }); err != nil {
+					return nil, err
+				}
+			}
+
+			if err := c.waitForAction(ctx, func(c *Client) (int, error) { return c.ResizeDroplet(d.ID, dr.Want) }); err != nil {
+				return nil, err
+			}
+
+			if !d.Status.IsOff() {
+				if err := c.waitForAction(ctx, func(c *Client) (int, error) { return c.PowerOnDroplet(d.ID) }); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return c.WithContext(ctx).GetDropletByID(d.ID)
+}
+
+// dropletDrift compares a droplet with a spec, resolving the slugs of the spec
+func (c *Client) dropletDrift(d *Droplet, spec NewDroplet) ([]Drift, error) {
+	var drifts []Drift
+
+	check := func(field string, id int, slug string, resolve func(string) (int, error), have int) error {
+		if id == 0 {
+			var err error
+			if id, err = resolve(slug); err != nil {
+				return err
+			}
+		}
+
+		if id != have {
+			drifts = append(drifts, Drift{Field: field, Want: id, Have: have})
+		}
+
+		return nil
+	}
+
+	if err := check("size", spec.SizeID, spec.SizeSlug, c.ResolveSizeSlug, d.SizeID); err != nil {
+		return nil, err
+	}
+
+	if err := check("image", spec.ImageID, spec.ImageSlug, c.ResolveImageSlug, d.ImageID); err != nil {
+		return nil, err
+	}
+
+	if err := check("region", spec.RegionID, spec.RegionSlug, c.ResolveRegionSlug, d.RegionID); err != nil {
+		return nil, err
+	}
+
+	return drifts, nil
+}
+
+// createAndWait creates a droplet and waits until it is active
+func (c *Client) createAndWait(ctx context.Context, spec NewDroplet) (*Droplet, error) {
+	pd, err := c.WithContext(ctx).CreateDroplet(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := c.WaitForEvent(ctx, pd.EventID); err != nil {
+		return nil, err
+	}
+
+	return c.WaitForDropletStatus(ctx, pd.ID, DropletStatusActive)
+}
+
+// waitForAction invokes an action which returns an event ID and waits for the event
+func (c *Client) waitForAction(ctx context.Context, action func(c *Client) (int, error)) error {
+	eventID, err := action(c.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	_, err = c.WaitForEvent(ctx, eventID)
+	return err
+}
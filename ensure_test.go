@@ -0,0 +1,62 @@
+package godo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEnsureDropletWithExpiryCreatesOnce(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		creates  int
+		droplets []Droplet
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		enc := json.NewEncoder(w)
+		switch r.URL.Path {
+		case "/droplets":
+			enc.Encode(map[string]any{"status": "OK", "droplets": droplets})
+		case "/droplets/new":
+			creates++
+			d := Droplet{ID: creates, Name: r.URL.Query().Get("name"), SizeID: 66, ImageID: 1, RegionID: 4, Status: DropletStatusActive}
+			droplets = append(droplets, d)
+			enc.Encode(map[string]any{"status": "OK", "droplet": map[string]any{"id": d.ID, "name": d.Name, "event_id": 1}})
+		case "/droplets/1":
+			enc.Encode(map[string]any{"status": "OK", "droplet": droplets[0]})
+		case "/events/1":
+			enc.Encode(map[string]any{"status": "OK", "event": Event{ID: "1", ActionStatus: EventStatusDone, Percentage: 100}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithCredentials("id", "key"), WithBaseURL(srv.URL), WithPollInterval(time.Millisecond))
+
+	for run := range 2 {
+		spec, err := NewDropletBuilder().Name("ci").SizeID(66).ImageID(1).RegionID(4).ExpiresIn(time.Duration(run+1) * time.Hour).Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		d, err := c.EnsureDroplet(context.Background(), spec, DriftReport)
+		if err != nil {
+			t.Fatalf("run %d: %v", run, err)
+		}
+		if d.ID != 1 {
+			t.Errorf("run %d: got droplet %d, want 1", run, d.ID)
+		}
+	}
+
+	if creates != 1 {
+		t.Errorf("created %d droplets, want 1", creates)
+	}
+}
@@ -0,0 +1,145 @@
+package godo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+)
+
+// envelope is the part of every API response which reports whether the request succeeded
+type envelope struct {
+	Status  Status `json:"status"`
+	Message string `json:"message"`
+}
+
+// decodeEnvelope reads a response object while it is received. The status and message are decoded into env, every other field is passed to field, which must decode or skip it, see skipValue.
+func decodeEnvelope(r io.Reader, env *envelope, field func(dec *json.Decoder, name string) error) error {
+	dec := json.NewDecoder(r)
+
+	return decodeObject(dec, func(name string) error {
+		switch name {
+		case "status":
+			return dec.Decode(&env.Status)
+		case "message":
+			return dec.Decode(&env.Message)
+		}
+
+		return field(dec, name)
+	})
+}
+
+// skipValue reads the next value of dec and discards it
+func skipValue(dec *json.Decoder) error {
+	var skip json.RawMessage
+	return dec.Decode(&skip)
+}
+
+// checkEnvelope returns the error the API reported in a response, as an APIError wrapped with "could not " followed by the formatted description of the call, and passes a message of a successful response to the warning handler
+func (c *Client) checkEnvelope(endpoint string, env envelope, format string, args ...interface{}) error {
+	if env.Status == StatusError {
+		return fmt.Errorf("could not %s: %w", fmt.Sprintf(format, args...), c.apiError(endpoint, 0, env.Message))
+	}
+
+	if env.Message != "" {
+		c.warn(endpoint, env.Message)
+	}
+
+	return nil
+}
+
+// WithWarningHandler calls fn with the non-fatal messages the API includes in successful responses
+func WithWarningHandler(fn func(endpoint, message string)) Option {
+	return func(c *Client) {
+		c.OnWarning = fn
+	}
+}
+
+// warn passes a message from a successful response to the warning handler, or logs it if there is none
+func (c *Client) warn(endpoint, message string) {
+	if c.OnWarning != nil {
+		c.OnWarning(endpoint, message)
+		return
+	}
+
+	if c.Logger != nil {
+		c.Logger.Warn("godo: API warning", slog.String("endpoint", endpoint), slog.String("message", message))
+	}
+}
+
+// decodeField decodes the field key of a response into a T. The zero value is returned if the field is missing or key is empty.
+func decodeField[T any](raw map[string]json.RawMessage, key string, format string, args ...interface{}) (T, error) {
+	var v T
+
+	if data, ok := raw[key]; ok && key != "" {
+		if err := json.Unmarshal(data, &v); err != nil {
+			return v, fmt.Errorf("could not %s: %v", fmt.Sprintf(format, args...), err)
+		}
+	}
+
+	return v, nil
+}
+
+// call sends a GET request to endpoint and decodes the field key of the response into a T while the response is received. The zero value is returned if the field is missing or key is empty. If the API reports an error, it is returned as described by checkEnvelope.
+func call[T any](c *Client, endpoint string, params url.Values, key string, format string, args ...interface{}) (T, error) {
+	var (
+		env envelope
+		v   T
+	)
+
+	err := c.request(http.MethodGet, endpoint, params, nil, func(r io.Reader) error {
+		return decodeEnvelope(r, &env, func(dec *json.Decoder, name string) error {
+			if name == key && key != "" {
+				return dec.Decode(&v)
+			}
+
+			return skipValue(dec)
+		})
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	if err := c.checkEnvelope(endpoint, env, format, args...); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return v, nil
+}
+
+// Do sends a request to an endpoint which this package doesn't wrap yet, and decodes the whole response into out if it is not nil. The request gets the client's authentication, retries, hooks and error handling, so an API error is returned as an APIError.
+func (c *Client) Do(ctx context.Context, method, endpoint string, params url.Values, out interface{}) error {
+	return c.DoWithBody(ctx, method, endpoint, params, nil, out)
+}
+
+// DoWithBody is like Do but sends in with the request, encoded as JSON unless it is nil or a json.RawMessage
+func (c *Client) DoWithBody(ctx context.Context, method, endpoint string, params url.Values, in, out interface{}) error {
+	var body json.RawMessage
+	if err := c.WithContext(ctx).doRequest(method, endpoint, params, in, &body); err != nil {
+		return err
+	}
+
+	// Only an object can be an envelope, so other bodies, e.g. an array, skip the envelope check; an object which isn't a valid envelope is an error
+	var env envelope
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := json.Unmarshal(trimmed, &env); err != nil {
+			return fmt.Errorf("could not decode response: %w", err)
+		}
+	}
+
+	if env.Status == StatusError {
+		return fmt.Errorf("could not %s %s: %w", method, endpoint, c.apiError(endpoint, 0, env.Message))
+	}
+
+	if out == nil || len(body) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(body, out)
+}
@@ -0,0 +1,49 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDoEnvelopeCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr error
+		// decodeErr is whether the response can't be decoded
+		decodeErr bool
+	}{
+		{"envelope", `{"status":"OK","value":1}`, nil, false},
+		{"error envelope", `{"status":"ERROR","message":"Not Found"}`, ErrNotFound, false},
+		{"array", ` [1, 2]`, nil, false},
+		{"invalid envelope", `{"status":5}`, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			c := NewClient(WithCredentials("id", "key"), WithBaseURL(srv.URL))
+			err := c.Do(context.Background(), http.MethodGet, "/unknown", nil, nil)
+
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("got error %v, want %v", err, tt.wantErr)
+				}
+			case tt.decodeErr:
+				if err == nil {
+					t.Error("invalid envelope was accepted")
+				}
+			case err != nil:
+				t.Errorf("got error %v", err)
+			}
+		})
+	}
+}
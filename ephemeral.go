@@ -0,0 +1,53 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ephemeralCleanupTimeout bounds how long destroying an ephemeral droplet may take once ctx is done
+const ephemeralCleanupTimeout = 2 * time.Minute
+
+// WithEphemeralDroplet creates a droplet from template, waits until it is active and invokes fn with it. The droplet is destroyed afterwards, even if fn fails, panics or ctx is canceled. A destroy refused while the droplet is still locked, e.g. by its create event, is retried with backoff for up to ephemeralCleanupTimeout. If the droplet can't be destroyed, the returned error names its ID so it can be destroyed by hand.
+func (c *Client) WithEphemeralDroplet(ctx context.Context, template NewDroplet, fn func(ctx context.Context, d *Droplet) error) (err error) {
+	pd, err := c.WithContext(ctx).CreateDroplet(template)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), ephemeralCleanupTimeout)
+		defer cancel()
+
+		if derr := c.destroyEphemeral(cleanupCtx, pd.ID); derr != nil {
+			err = errors.Join(err, fmt.Errorf("could not destroy ephemeral droplet with ID %d, it has to be destroyed by hand: %w", pd.ID, derr))
+		}
+	}()
+
+	if _, err = c.WaitForEvent(ctx, pd.EventID); err != nil {
+		return err
+	}
+
+	d, err := c.WaitForDropletStatus(ctx, pd.ID, DropletStatusActive)
+	if err != nil {
+		return err
+	}
+
+	return fn(ctx, d)
+}
+
+// destroyEphemeral destroys a droplet, retrying with backoff until ctx is done. A droplet which is already gone counts as destroyed.
+func (c *Client) destroyEphemeral(ctx context.Context, ID int) error {
+	for attempt := 0; ; attempt++ {
+		_, err := c.WithContext(ctx).DeleteDropletByID(ID)
+		if err == nil || errors.Is(err, ErrNotFound) {
+			return nil
+		}
+
+		if sleepErr := sleepContext(ctx, backoff(attempt)); sleepErr != nil {
+			return errors.Join(err, sleepErr)
+		}
+	}
+}
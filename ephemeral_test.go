@@ -0,0 +1,50 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEphemeralDropletDestroyRetriedWhileLocked(t *testing.T) {
+	var destroys atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/droplets/new":
+			fmt.Fprint(w, `{"status":"OK","droplet":{"id":7,"name":"tmp","event_id":1}}`)
+		case "/events/1":
+			fmt.Fprint(w, `{"status":"OK","event":{"id":"1","action_status":"done","droplet_id":7,"percentage":100}}`)
+		case "/droplets/7":
+			fmt.Fprint(w, `{"status":"OK","droplet":{"id":7,"name":"tmp","status":"active"}}`)
+		case "/droplets/7/destroy":
+			if destroys.Add(1) == 1 {
+				fmt.Fprint(w, `{"status":"ERROR","message":"Droplet is currently locked"}`)
+				return
+			}
+			fmt.Fprint(w, `{"status":"OK","event_id":2}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithCredentials("id", "key"), WithBaseURL(srv.URL), WithPollInterval(time.Millisecond))
+	template := NewDroplet{Name: "tmp", SizeSlug: "512mb", ImageSlug: "ubuntu", RegionSlug: "nyc2"}
+
+	errFn := errors.New("provisioning failed")
+	err := c.WithEphemeralDroplet(context.Background(), template, func(ctx context.Context, d *Droplet) error {
+		return errFn
+	})
+
+	if err != errFn {
+		t.Errorf("got error %v, want only %v", err, errFn)
+	}
+	if got := destroys.Load(); got != 2 {
+		t.Errorf("sent %d destroys, want 2", got)
+	}
+}
@@ -0,0 +1,178 @@
+package godo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrNotFound is matched by errors for resources which don't exist
+	ErrNotFound = errors.New("resource not found")
+	// ErrUnauthorized is matched by errors caused by missing or invalid credentials
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrRateLimited is matched by errors caused by sending too many requests
+	ErrRateLimited = errors.New("rate limited")
+	// ErrDropletLocked is matched by errors caused by a droplet being locked by a pending event
+	ErrDropletLocked = errors.New("droplet is locked")
+	// ErrReadOnly is matched by errors for mutating calls refused by a read-only client
+	ErrReadOnly = errors.New("client is read-only")
+	// ErrResponseTooLarge is matched by errors for response bodies larger than the client's MaxResponseSize
+	ErrResponseTooLarge = errors.New("response is too large")
+	// ErrAccountSuspended is matched by errors caused by the account being suspended
+	ErrAccountSuspended = errors.New("account is suspended")
+	// ErrPaymentRequired is matched by errors caused by an unpaid balance of the account
+	ErrPaymentRequired = errors.New("payment required")
+	// ErrAmbiguousName is matched by errors for a name shared by several resources where one is expected
+	ErrAmbiguousName = errors.New("name is ambiguous")
+	// ErrEventFailed is matched by errors for events which finished with an error
+	ErrEventFailed = errors.New("event failed")
+)
+
+// APIError is an error reported by the API. It matches the sentinel errors of this package with errors.Is.
+type APIError struct {
+	// StatusCode is the HTTP status of the response, or 0 if the API reported the error in a successful response
+	StatusCode int
+	// Message is the message of the API, which is used to classify the error
+	Message  string
+	Endpoint string
+
+	// Code, Text and Help are empty unless set by the error hook of the client, see WithErrorHook
+	Code string
+	Text string
+	Help string
+}
+
+// WithErrorHook calls fn with every error reported by the API before it is returned, so fn can present it consistently to operators: Code gives the error a stable code, Text replaces the message, e.g. with a translation, and Help adds guidance such as a runbook link. The message of the API stays available in Message.
+func WithErrorHook(fn func(e *APIError)) Option {
+	return func(c *Client) {
+		c.errorHook = fn
+	}
+}
+
+// apiError returns an APIError after passing it to the error hook
+func (c *Client) apiError(endpoint string, status int, msg string) error {
+	e := &APIError{StatusCode: status, Message: msg, Endpoint: endpoint}
+	if c.errorHook != nil {
+		c.errorHook(e)
+	}
+
+	if err := accountError(e); err != nil {
+		return err
+	}
+
+	return e
+}
+
+// AccountBillingURL is the page where the billing of an account is managed
+const AccountBillingURL = "https://cloud.digitalocean.com/account/billing"
+
+// AccountError is returned when the API refuses a call because of the state of the account rather than the call itself. Retrying is pointless until someone acts on the account, so callers should alert its owners with Action instead. It unwraps to the APIError, which matches Reason with errors.Is.
+type AccountError struct {
+	// Reason is ErrAccountSuspended or ErrPaymentRequired
+	Reason error
+	// Action tells who has to do what to lift the restriction
+	Action string
+	// URL is where the account can be managed
+	URL string
+	Err *APIError
+}
+
+func (e *AccountError) Error() string {
+	return fmt.Sprintf("%v: %s, %s at %s", e.Reason, e.Err, e.Action, e.URL)
+}
+
+func (e *AccountError) Unwrap() error {
+	return e.Err
+}
+
+// accountError returns an AccountError for an API error caused by the state of the account, or nil
+func accountError(e *APIError) *AccountError {
+	switch {
+	case e.Is(ErrAccountSuspended):
+		return &AccountError{
+			Reason: ErrAccountSuspended,
+			Action: "the account owner has to contact support to reinstate the account",
+			URL:    AccountBillingURL,
+			Err:    e,
+		}
+	case e.Is(ErrPaymentRequired):
+		return &AccountError{
+			Reason: ErrPaymentRequired,
+			Action: "the billing owner has to settle the balance or update the payment method",
+			URL:    AccountBillingURL,
+			Err:    e,
+		}
+	}
+
+	return nil
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if e.Text != "" {
+		msg = e.Text
+	}
+
+	if e.Code != "" {
+		msg = e.Code + ": " + msg
+	}
+
+	if e.Help != "" {
+		msg += " (see " + e.Help + ")"
+	}
+
+	return msg
+}
+
+// apiErrorMessages match the messages of the v1 API for the errors which aren't told apart by their HTTP status. They are anchored at both ends, so a message merely mentioning a word like "billing" or "locked" doesn't match.
+var apiErrorMessages = map[error]*regexp.Regexp{
+	ErrNotFound:         regexp.MustCompile(`(?i)^(?:(?:the )?(?:resource|droplet|image|domain|record|ssh key|event)(?: with id \d+)? )?not found\.?$|^(?:could not find|no) (?:droplet|image|domain|record|ssh key|event)s?(?: with id \d+)?(?: found)?\.?$`),
+	ErrUnauthorized:     regexp.MustCompile(`(?i)^(?:access denied|unauthorized|invalid api key|invalid client id)\.?$`),
+	ErrRateLimited:      regexp.MustCompile(`(?i)^(?:rate limit exceeded|too many requests)\.?$`),
+	ErrDropletLocked:    regexp.MustCompile(`(?i)^(?:(?:the )?droplet(?: \d+)? is (?:currently )?locked|(?:there is already a|droplet already has a) pending event(?: for (?:this|the) droplet)?)\.?$`),
+	ErrAccountSuspended: regexp.MustCompile(`(?i)^(?:your )?account (?:has been |is )?suspended\.?$`),
+	ErrPaymentRequired:  regexp.MustCompile(`(?i)^(?:payment required|(?:your )?account is past due|(?:your account has an )?outstanding balance(?: must be paid)?)\.?$`),
+}
+
+// Is reports whether the error is of the kind of target, based on the HTTP status and the message
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		if e.StatusCode == http.StatusNotFound {
+			return true
+		}
+	case ErrUnauthorized:
+		if e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden {
+			return true
+		}
+	case ErrRateLimited:
+		if e.StatusCode == http.StatusTooManyRequests {
+			return true
+		}
+	case ErrPaymentRequired:
+		if e.StatusCode == http.StatusPaymentRequired {
+			return true
+		}
+	}
+
+	re, ok := apiErrorMessages[target]
+	return ok && re.MatchString(strings.TrimSpace(e.Message))
+}
+
+// isCapacityError reports whether the API refused to create a resource because the region lacks capacity or is unavailable
+func isCapacityError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	msg := strings.ToLower(apiErr.Message)
+	return strings.Contains(msg, "capacity") ||
+		strings.Contains(msg, "region is not available") ||
+		strings.Contains(msg, "region is unavailable") ||
+		strings.Contains(msg, "not available in this region") ||
+		strings.Contains(msg, "sold out")
+}
@@ -0,0 +1,61 @@
+package godo
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestAPIErrorIs(t *testing.T) {
+	tests := []struct {
+		status  int
+		message string
+		target  error
+		want    bool
+	}{
+		{http.StatusNotFound, "", ErrNotFound, true},
+		{0, "Not Found", ErrNotFound, true},
+		{0, "Droplet with ID 123 not found.", ErrNotFound, true},
+		{0, "Could not find droplet", ErrNotFound, true},
+		{0, "No Droplets Found", ErrNotFound, true},
+		{0, "Image not found in the region, pick another one", ErrNotFound, false},
+		{0, "Size is not found in this region's list of sizes", ErrNotFound, false},
+
+		{http.StatusForbidden, "", ErrUnauthorized, true},
+		{0, "Access Denied", ErrUnauthorized, true},
+		{0, "Invalid API Key", ErrUnauthorized, true},
+		{0, "Access denied to the droplet console is logged", ErrUnauthorized, false},
+
+		{http.StatusTooManyRequests, "", ErrRateLimited, true},
+		{0, "Rate limit exceeded", ErrRateLimited, true},
+		{0, "Rate limit of the droplet network was raised", ErrRateLimited, false},
+
+		{0, "Droplet is currently locked", ErrDropletLocked, true},
+		{0, "Droplet 123 is locked.", ErrDropletLocked, true},
+		{0, "There is already a pending event for this droplet", ErrDropletLocked, true},
+		{0, "Droplet is unlocked", ErrDropletLocked, false},
+		{0, "The image is locked by another user", ErrDropletLocked, false},
+		{0, "Locked droplets can't be resized, power off first", ErrDropletLocked, false},
+
+		{0, "Your account has been suspended", ErrAccountSuspended, true},
+		{0, "Backups are suspended during maintenance", ErrAccountSuspended, false},
+
+		{http.StatusPaymentRequired, "", ErrPaymentRequired, true},
+		{0, "Payment required", ErrPaymentRequired, true},
+		{0, "Your account is past due.", ErrPaymentRequired, true},
+		{0, "Outstanding balance must be paid", ErrPaymentRequired, true},
+		{0, "Billing address is invalid", ErrPaymentRequired, false},
+		{0, "Name must not contain the word billing", ErrPaymentRequired, false},
+		{0, "Payment method was updated", ErrPaymentRequired, false},
+
+		{0, "Not Found", ErrUnauthorized, false},
+		{0, "Not Found", ErrReadOnly, false},
+	}
+
+	for _, tt := range tests {
+		err := &APIError{StatusCode: tt.status, Message: tt.message}
+		if got := errors.Is(err, tt.target); got != tt.want {
+			t.Errorf("errors.Is(APIError{%d, %q}, %v) = %v, want %v", tt.status, tt.message, tt.target, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+// Command bluegreen replaces the droplet behind a DNS A record: it provisions a droplet of the other color than the live one, waits for its SSH server, points the record to it and then destroys the old droplet. The colors are kept as godo identities, e.g. "app-blue" and "app-green". If a step fails, the completed ones are undone, so the live droplet keeps serving.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/pengux/godo"
+)
+
+var colors = [2]string{"blue", "green"}
+
+func main() {
+	var (
+		app     = flag.String("app", "app", "name of the droplets")
+		domain  = flag.String("domain", "", "domain of the record, e.g. example.com")
+		record  = flag.String("record", "@", "name of the A record pointing to the live droplet")
+		image   = flag.String("image", "", "slug of the image to deploy")
+		size    = flag.String("size", "512mb", "slug of the size")
+		region  = flag.String("region", "nyc2", "slug of the region")
+		preview = flag.Bool("preview", false, "print the calls which would be made instead of making them")
+	)
+	flag.Parse()
+
+	if *domain == "" || *image == "" {
+		log.Fatal("-domain and -image must be set")
+	}
+
+	c, err := godo.NewClientFromEnv(godo.WithOperationTimeout(15 * time.Minute))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	spec, err := godo.NewDropletBuilder().
+		Name(*app).
+		ImageSlug(*image).
+		SizeSlug(*size).
+		RegionSlug(*region).
+		Build()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	pointTo := func(ctx context.Context, c *godo.Client, st *godo.WorkflowState, ip string) error {
+		r := godo.DomainRecord{ID: st.Int("record"), RecordType: "A", Name: *record, Data: ip}
+		if r.ID == 0 {
+			created, err := c.WithContext(ctx).CreateDomainRecord(*domain, r)
+			if err != nil {
+				return err
+			}
+			st.SetInt("record", created.ID)
+			return nil
+		}
+
+		_, err := c.WithContext(ctx).UpdateRecordByDomain(*domain, r)
+		return err
+	}
+
+	wf := godo.NewWorkflow("bluegreen-"+*app,
+		godo.WorkflowStep{
+			Name: "provision",
+			Run: func(ctx context.Context, c *godo.Client, st *godo.WorkflowState) (int, error) {
+				records, err := c.WithContext(ctx).GetAllRecordsByDomain(*domain)
+				if err != nil {
+					return 0, err
+				}
+
+				var liveIP string
+				for _, r := range records {
+					if r.RecordType == "A" && r.Name == *record {
+						st.SetInt("record", r.ID)
+						liveIP = r.Data
+					}
+				}
+
+				next := colors[0]
+				for i, color := range colors {
+					d, err := c.GetDropletByIdentity(ctx, spec.Name, *app+"-"+color)
+					if err == nil && liveIP != "" && d.IPAdress == liveIP {
+						st.SetInt("old", d.ID)
+						st.Set("oldIP", liveIP)
+						next = colors[1-i]
+					}
+				}
+
+				spec := spec
+				spec.Identity = *app + "-" + next
+
+				d, err := c.EnsureDroplet(ctx, spec, godo.DriftReport)
+				if err != nil {
+					return 0, err
+				}
+				st.SetInt("new", d.ID)
+				st.Set("newIP", d.IPAdress)
+
+				return 0, c.WaitForSSH(ctx, d)
+			},
+			Compensate: func(ctx context.Context, c *godo.Client, st *godo.WorkflowState) error {
+				return c.DeleteDropletAndWait(ctx, st.Int("new"))
+			},
+		},
+		godo.WorkflowStep{
+			Name: "switch",
+			Run: func(ctx context.Context, c *godo.Client, st *godo.WorkflowState) (int, error) {
+				return 0, pointTo(ctx, c, st, st.Get("newIP"))
+			},
+			Compensate: func(ctx context.Context, c *godo.Client, st *godo.WorkflowState) error {
+				if st.Get("oldIP") == "" {
+					return nil
+				}
+
+				return pointTo(ctx, c, st, st.Get("oldIP"))
+			},
+		},
+		godo.WorkflowStep{
+			Name: "retire",
+			Run: func(ctx context.Context, c *godo.Client, st *godo.WorkflowState) (int, error) {
+				if st.Int("old") == 0 {
+					return 0, nil
+				}
+
+				return 0, c.DeleteDropletAndWait(ctx, st.Int("old"))
+			},
+		},
+	)
+
+	ctx := context.Background()
+	if *preview {
+		plan, err := wf.Explain(ctx, c, nil)
+		for _, call := range plan {
+			fmt.Println(call.Method, call.Endpoint, call.Params.Encode())
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	st := &godo.WorkflowState{}
+	if err := wf.Run(ctx, c, st); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("record %s of %s now points to droplet %d at %s\n", *record, *domain, st.Int("new"), st.Get("newIP"))
+}
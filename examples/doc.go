@@ -0,0 +1,8 @@
+// Package examples holds runnable recipes built only on the public API of godo. Each recipe is a command in a subdirectory which reads its credentials from the DIGITALOCEAN_CLIENT_ID and DIGITALOCEAN_API_KEY environment variables and takes its parameters as flags:
+//
+//	lamp       provisions a LAMP droplet and points a domain to it
+//	snapshots  takes a snapshot of a droplet and deletes the oldest ones
+//	bluegreen  replaces the droplet behind a DNS record without downtime
+//
+// Every recipe accepts -preview to print the calls it would make instead of making them.
+package examples
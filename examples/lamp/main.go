@@ -0,0 +1,85 @@
+// Command lamp provisions a droplet from a LAMP image and points a domain and its www subdomain to it. Running it again converges the droplet and the records instead of duplicating them.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"github.com/pengux/godo"
+)
+
+func main() {
+	var (
+		name    = flag.String("name", "lamp", "name of the droplet")
+		domain  = flag.String("domain", "", "domain to point to the droplet, e.g. example.com")
+		image   = flag.String("image", "", "slug of the LAMP image")
+		size    = flag.String("size", "512mb", "slug of the size")
+		region  = flag.String("region", "nyc2", "slug of the region")
+		preview = flag.Bool("preview", false, "print the calls which would be made instead of making them")
+	)
+	flag.Parse()
+
+	if *domain == "" || *image == "" {
+		log.Fatal("-domain and -image must be set")
+	}
+
+	c, err := godo.NewClientFromEnv(godo.WithOperationTimeout(15 * time.Minute))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	spec, err := godo.NewDropletBuilder().
+		Name(*name).
+		ImageSlug(*image).
+		SizeSlug(*size).
+		RegionSlug(*region).
+		Build()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	provision := func(ctx context.Context, c *godo.Client) error {
+		d, err := c.EnsureDroplet(ctx, spec, godo.DriftReport)
+		if err != nil {
+			return err
+		}
+
+		ip := net.ParseIP(d.IPAdress)
+		if ip == nil && !c.DryRun() {
+			return fmt.Errorf("droplet %q has no public address", d.Name)
+		}
+
+		report, err := c.EnsureDomain(ctx, *domain, ip, []godo.DomainRecord{
+			{RecordType: "CNAME", Name: "www", Data: *domain + "."},
+		})
+		if err != nil {
+			return err
+		}
+
+		if !c.DryRun() {
+			fmt.Printf("droplet %q is at %s, %d records of %s changed\n", d.Name, ip, len(report.Created)+len(report.Updated)+len(report.Deleted), *domain)
+		}
+
+		return nil
+	}
+
+	ctx := context.Background()
+	if !*preview {
+		if err := provision(ctx, c); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	plan, err := c.Explain(ctx, provision)
+	for _, call := range plan {
+		fmt.Println(call.Method, call.Endpoint, call.Params.Encode())
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,96 @@
+// Command snapshots takes a snapshot of a droplet and deletes its oldest snapshots, keeping the given number. Snapshots are recognized by the names given by godo.NameSnapshot, so other images are never deleted.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/pengux/godo"
+)
+
+func main() {
+	var (
+		name    = flag.String("droplet", "", "name of the droplet")
+		keep    = flag.Int("keep", 7, "number of snapshots to keep")
+		preview = flag.Bool("preview", false, "print the calls which would be made instead of making them")
+	)
+	flag.Parse()
+
+	if *name == "" || *keep < 1 {
+		log.Fatal("-droplet must be set and -keep must be at least 1")
+	}
+
+	c, err := godo.NewClientFromEnv(godo.WithOperationTimeout(time.Hour))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rotate := func(ctx context.Context, c *godo.Client) error {
+		d, err := c.WithContext(ctx).GetDropletByName(*name)
+		if err != nil {
+			return err
+		}
+
+		eventID, err := c.WithContext(ctx).TakeSnapshotOnDroplet(d.ID, godo.NameSnapshot(*d, time.Now()))
+		if err != nil {
+			return err
+		}
+
+		if _, err := c.WaitForEvent(ctx, eventID); err != nil {
+			return err
+		}
+
+		images, err := c.WithContext(ctx).GetMyImages()
+		if err != nil {
+			return err
+		}
+
+		type snapshot struct {
+			image godo.Image
+			name  godo.SnapshotName
+		}
+
+		var snapshots []snapshot
+		for _, i := range images {
+			if sn, ok := godo.ParseSnapshotName(i.Name); ok && sn.DropletID == d.ID {
+				snapshots = append(snapshots, snapshot{i, sn})
+			}
+		}
+
+		// Newest first, so the ones past keep are the oldest
+		sort.Slice(snapshots, func(i, j int) bool {
+			return snapshots[i].name.TakenAt.After(snapshots[j].name.TakenAt)
+		})
+
+		for _, s := range snapshots[min(*keep, len(snapshots)):] {
+			if err := c.WithContext(ctx).DeleteImage(s.image.ID); err != nil {
+				return err
+			}
+			if !c.DryRun() {
+				fmt.Println("deleted snapshot", s.image.Name)
+			}
+		}
+
+		return nil
+	}
+
+	ctx := context.Background()
+	if !*preview {
+		if err := rotate(ctx, c); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	plan, err := c.Explain(ctx, rotate)
+	for _, call := range plan {
+		fmt.Println(call.Method, call.Endpoint, call.Params.Encode())
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,62 @@
+package godo
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// expiryLayout is the format of the deadline encoded in droplet names. It only uses characters valid in hostnames.
+const expiryLayout = "20060102t1504z"
+
+var expiryPattern = regexp.MustCompile(`(?:^|-)exp-(\d{8}t\d{4}z)(?:-|$)`)
+
+// WithExpiry returns name with a suffix encoding the deadline after which ReapExpired destroys the droplet, e.g. "ci-runner-exp-20141016t1500z"
+func WithExpiry(name string, deadline time.Time) string {
+	return fmt.Sprintf("%s-exp-%s", name, strings.ToLower(deadline.UTC().Format(expiryLayout)))
+}
+
+// ParseExpiry returns the deadline encoded in a droplet name by WithExpiry
+func ParseExpiry(name string) (time.Time, bool) {
+	m := expiryPattern.FindStringSubmatch(name)
+	if m == nil {
+		return time.Time{}, false
+	}
+
+	t, err := time.Parse(expiryLayout, m[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
+// withoutExpiry returns name without the suffix added by WithExpiry, if there is one
+func withoutExpiry(name string) string {
+	return expirySuffix.ReplaceAllString(name, "")
+}
+
+// ReapExpired destroys all droplets whose name encodes a deadline which has passed and returns them
+func (c *Client) ReapExpired(ctx context.Context) ([]Droplet, error) {
+	droplets, err := c.WithContext(ctx).GetAllDroplets()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		expired []Droplet
+		now     = time.Now()
+	)
+
+	for _, d := range droplets {
+		if deadline, ok := ParseExpiry(d.Name); ok && !deadline.After(now) {
+			expired = append(expired, d)
+		}
+	}
+
+	r := c.DeleteDroplets(ctx, expired)
+
+	return r.Succeeded, r.Err()
+}
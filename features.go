@@ -0,0 +1,129 @@
+package godo
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Feature is an optional capability which might not be offered for every account or region
+type Feature string
+
+const (
+	// FeaturePrivateNetworking is the private networking capability of droplets
+	FeaturePrivateNetworking Feature = "private networking"
+	// FeatureBackups is the automatic backups capability of droplets
+	FeatureBackups Feature = "backups"
+)
+
+// ErrUnsupported is returned when a feature is not offered for the account or region
+var ErrUnsupported = errors.New("feature is not supported")
+
+// UnsupportedError is returned by helpers that depend on a feature which the API has reported as unavailable. It matches ErrUnsupported with errors.Is.
+type UnsupportedError struct {
+	Feature Feature
+	Message string
+}
+
+func (e *UnsupportedError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%s is not supported", e.Feature)
+	}
+
+	return fmt.Sprintf("%s is not supported: %s", e.Feature, e.Message)
+}
+
+// Is reports whether target is ErrUnsupported
+func (e *UnsupportedError) Is(target error) bool {
+	return target == ErrUnsupported
+}
+
+// featureRefusalTTL is how long a feature the API has reported as unavailable is assumed to stay so, since it may be enabled for the account later
+const featureRefusalTTL = time.Hour
+
+// featureKey identifies a feature in a region. The region is empty when the refusal didn't concern a known region.
+type featureKey struct {
+	feature Feature
+	region  string
+}
+
+type featureRefusal struct {
+	message string
+	at      time.Time
+}
+
+// featureSet remembers which features the API has reported as unavailable, per region, so the check only has to fail once until the refusal expires
+type featureSet struct {
+	mu          sync.RWMutex
+	unsupported map[featureKey]featureRefusal
+	now         func() time.Time
+}
+
+func newFeatureSet() *featureSet {
+	return &featureSet{unsupported: make(map[featureKey]featureRefusal), now: time.Now}
+}
+
+// check returns an UnsupportedError if f was refused without a known region or in one of regions, which are the identifiers of a single region, e.g. its slug and its ID
+func (s *featureSet) check(f Feature, regions ...string) error {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := s.now()
+	for _, region := range append([]string{""}, regions...) {
+		r, ok := s.unsupported[featureKey{f, region}]
+		if ok && now.Sub(r.at) < featureRefusalTTL {
+			return &UnsupportedError{Feature: f, Message: r.message}
+		}
+	}
+
+	return nil
+}
+
+// checkRegion checks f in r, whose refusals may be recorded under its slug or its ID
+func (s *featureSet) checkRegion(f Feature, r Region) error {
+	return s.check(f, r.Slug, strconv.Itoa(r.ID))
+}
+
+// detect inspects an API error message of a request in region, empty if unknown, and records the features it reports as unavailable there. It returns an UnsupportedError for the first one found.
+func (s *featureSet) detect(msg, region string, features ...Feature) error {
+	if s == nil {
+		return nil
+	}
+
+	lower := strings.ToLower(msg)
+	if !strings.Contains(lower, "not available") &&
+		!strings.Contains(lower, "not supported") &&
+		!strings.Contains(lower, "unavailable") &&
+		!strings.Contains(lower, "not enabled") {
+		return nil
+	}
+
+	for _, f := range features {
+		if strings.Contains(lower, string(f)) {
+			s.mu.Lock()
+			s.unsupported[featureKey{f, region}] = featureRefusal{message: msg, at: s.now()}
+			s.mu.Unlock()
+
+			return &UnsupportedError{Feature: f, Message: msg}
+		}
+	}
+
+	return nil
+}
+
+// Supports returns false if the API has recently reported that the feature is not offered for this client's account, outside of a particular region. Features are assumed to be supported until proven otherwise, and refusals are forgotten after an hour.
+func (c *Client) Supports(f Feature) bool {
+	return c.features.check(f) == nil
+}
+
+// SupportsInRegion is like Supports, but also returns false if the feature was refused in the region with the given slug or ID
+func (c *Client) SupportsInRegion(f Feature, region string) bool {
+	return c.features.check(f, region) == nil
+}
@@ -0,0 +1,31 @@
+package godo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFeatureSetRefusals(t *testing.T) {
+	now := time.Now()
+	s := newFeatureSet()
+	s.now = func() time.Time { return now }
+
+	if err := s.detect("Private networking is not available in this region", "nyc2", FeaturePrivateNetworking); err == nil {
+		t.Fatal("refusal wasn't detected")
+	}
+
+	if s.check(FeaturePrivateNetworking, "nyc2") == nil {
+		t.Error("refusal in nyc2 wasn't remembered")
+	}
+	if err := s.check(FeaturePrivateNetworking, "ams1"); err != nil {
+		t.Errorf("refusal in nyc2 applies to ams1: %v", err)
+	}
+	if err := s.check(FeaturePrivateNetworking); err != nil {
+		t.Errorf("refusal in nyc2 applies to the account: %v", err)
+	}
+
+	now = now.Add(featureRefusalTTL)
+	if err := s.check(FeaturePrivateNetworking, "nyc2"); err != nil {
+		t.Errorf("refusal didn't expire: %v", err)
+	}
+}
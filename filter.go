@@ -0,0 +1,113 @@
+package godo
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"time"
+)
+
+// DropletFilter selects droplets by their fields. Unset criteria match every droplet.
+type DropletFilter struct {
+	Status DropletStatus
+
+	// RegionSlug is resolved to a region ID, it is ignored if RegionID is set
+	RegionID   int
+	RegionSlug string
+
+	// SizeSlug is resolved to a size ID, it is ignored if SizeID is set
+	SizeID   int
+	SizeSlug string
+
+	// Name is a glob pattern the name must match, e.g. "web-*"
+	Name string
+	// NameRegexp is a regular expression the name must match
+	NameRegexp *regexp.Regexp
+
+	CreatedBefore time.Time
+	CreatedAfter  time.Time
+}
+
+// Match reports whether the droplet meets every criterion of the filter. Slugs must be resolved beforehand, see ListDroplets.
+func (f DropletFilter) Match(d Droplet) bool {
+	if f.Status != "" && d.Status != f.Status {
+		return false
+	}
+
+	if f.RegionID != 0 && d.RegionID != f.RegionID {
+		return false
+	}
+
+	if f.SizeID != 0 && d.SizeID != f.SizeID {
+		return false
+	}
+
+	if f.Name != "" {
+		if ok, _ := path.Match(f.Name, d.Name); !ok {
+			return false
+		}
+	}
+
+	if f.NameRegexp != nil && !f.NameRegexp.MatchString(d.Name) {
+		return false
+	}
+
+	if !f.CreatedBefore.IsZero() && !d.CreatedAt.Before(f.CreatedBefore) {
+		return false
+	}
+
+	if !f.CreatedAfter.IsZero() && !d.CreatedAt.After(f.CreatedAfter) {
+		return false
+	}
+
+	return true
+}
+
+// resolve returns the filter with its slugs resolved to IDs
+func (f DropletFilter) resolve(c *Client) (DropletFilter, error) {
+	if f.Name != "" {
+		if _, err := path.Match(f.Name, ""); err != nil {
+			return f, fmt.Errorf("invalid name pattern %q: %w", f.Name, err)
+		}
+	}
+
+	if f.RegionID == 0 && f.RegionSlug != "" {
+		id, err := c.ResolveRegionSlug(f.RegionSlug)
+		if err != nil {
+			return f, err
+		}
+		f.RegionID = id
+	}
+
+	if f.SizeID == 0 && f.SizeSlug != "" {
+		id, err := c.ResolveSizeSlug(f.SizeSlug)
+		if err != nil {
+			return f, err
+		}
+		f.SizeID = id
+	}
+
+	return f, nil
+}
+
+// ListDroplets returns the droplets matching filter. The API can't filter droplets, so all of them are fetched and filtered on the client.
+func (c *Client) ListDroplets(filter DropletFilter) ([]Droplet, error) {
+	filter, err := filter.resolve(c)
+	if err != nil {
+		return nil, fmt.Errorf("could not list droplets: %w", err)
+	}
+
+	droplets, err := c.GetAllDroplets()
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []Droplet
+	for _, d := range droplets {
+		if filter.Match(d) {
+			matched = append(matched, d)
+		}
+	}
+
+	return matched, nil
+}
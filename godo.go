@@ -1,11 +1,18 @@
 package godo
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"io"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"os"
 	"strings"
+	"time"
 )
 
 // Status is the response status from API after each request
@@ -17,14 +24,101 @@ const (
 	// StatusError indicates that there was an error while processing the request, more information about the error should be available in the "message" field of the response
 	StatusError Status = "ERROR"
 
-	// APIURL is the URL for Digitalocean's API
+	// DefaultConcurrency is how many requests helpers which fan out send at once, unless configured with WithConcurrency
+	DefaultConcurrency = 4
+
+	// DefaultMaxResponseSize is the size in bytes of the largest response body a client reads, unless configured with WithMaxResponseSize
+	DefaultMaxResponseSize = 64 << 20
+
+	// DefaultTimeout is the timeout of each request unless the client is configured with WithTimeout or WithHTTPClient
+	DefaultTimeout = 60 * time.Second
+
+	// Version is the version of this package, reported in the User-Agent header
+	Version = "0.2.0"
+
+	// APIURL is the default URL for Digitalocean's API
 	APIURL = "https://api.digitalocean.com/v1"
+
+	// EnvClientID is the environment variable read by NewClientFromEnv for the client ID
+	EnvClientID = "DIGITALOCEAN_CLIENT_ID"
+	// EnvAPIKey is the environment variable read by NewClientFromEnv for the API key
+	EnvAPIKey = "DIGITALOCEAN_API_KEY"
 )
 
 // Client represents a new client which sends request to the API
 type Client struct {
 	ClientID string
 	APIKey   string
+
+	// BaseURL is the URL of the API which requests are sent to, defaults to APIURL. It can be changed to point the client at a mock server, a proxy or a regional endpoint.
+	BaseURL string
+
+	// HTTPClient is used to send requests, defaults to http.DefaultClient
+	HTTPClient *http.Client
+
+	// UserAgent is appended to the base User-Agent "godo/<version>" sent with every request
+	UserAgent string
+
+	// Middleware is applied to every request, the first one being the outermost
+	Middleware []Middleware
+
+	// MaxListItems caps the number of items the list methods fetch, 0 means no limit
+	MaxListItems int
+
+	// Concurrency bounds how many requests helpers which fan out, e.g. ExportInventory, send at once. It defaults to DefaultConcurrency.
+	Concurrency int
+
+	// MaxResponseSize is the size in bytes of the largest response body the client reads, after decompression. It defaults to DefaultMaxResponseSize; a negative size means no limit.
+	MaxResponseSize int64
+
+	// RegionFallback lists the slugs of the regions in which CreateDroplet tries again when a region lacks capacity
+	RegionFallback []string
+
+	// Retries is how many times a request is retried after a network error or a server error response
+	Retries int
+
+	// Logger records every API call when set, successful ones at LogLevel
+	Logger   *slog.Logger
+	LogLevel slog.Level
+
+	// OnWarning is called with the non-fatal messages the API includes in successful responses. They are logged to Logger if it is nil.
+	OnWarning func(endpoint, message string)
+
+	// Metrics receives a measurement for every API call when set
+	Metrics MetricsCollector
+
+	// Tracer starts a span for every API call when set
+	Tracer Tracer
+
+	// OperationTimeout bounds how long high-level operations wait for an event or a droplet, unless the context passed to them expires earlier. No limit is applied if it is 0.
+	OperationTimeout time.Duration
+
+	// RestartBudget tracks reboots and power cycles issued by the client when set
+	RestartBudget *RestartBudget
+
+	// PollInterval is how often events and droplets are polled while waiting for them, defaults to DefaultPollInterval
+	PollInterval time.Duration
+
+	// RateLimiter throttles all requests sent by the client when set
+	RateLimiter *RateLimiter
+
+	ctx            context.Context
+	requestTimeout time.Duration
+	features       *featureSet
+	rate           *rateState
+	traffic        *trafficCounter
+	progress       *Progress
+	slugs          *slugCache
+	flights        *flightGroup
+	dryRun         *dryRunPlan
+	readOnly       bool
+	pool           *credentialPool
+	standby        *standbyCredentials
+	reauth         *reauthenticator
+	cache          *responseCache
+	boots          *bootTracker
+	errorHook      func(e *APIError)
+	events         *eventPoller
 }
 
 // Event represents a event at DigitalOcean
@@ -41,6 +135,10 @@ type Region struct {
 	ID   int    `json:"id"`
 	Name string `json:"name"`
 	Slug string `json:"slug"`
+
+	// Sizes and Features are only set when reported by the API
+	Sizes    []string `json:"sizes"`
+	Features []string `json:"features"`
 }
 
 // Size represents a droplet size
@@ -55,99 +153,387 @@ type Size struct {
 	CostPerMonth string  `json:"cost_per_month"`
 }
 
-// NewClient returns a new Client struct
-func NewClient(clientID string, apiKey string) *Client {
-	return &Client{
-		clientID,
-		apiKey,
+// NewClient returns a new Client struct configured by the given options
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		BaseURL:    APIURL,
+		HTTPClient: &http.Client{Timeout: DefaultTimeout},
+		features:   newFeatureSet(),
+		rate:       &rateState{},
+		traffic:    newTrafficCounter(),
+		slugs:      newSlugCache(DefaultSlugCacheTTL),
 	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
-// GetEventByID returns information about an event by its ID
-func (c *Client) GetEventByID(ID int) (*Event, error) {
-	var DOResp struct {
-		Status  Status `json:"status"`
-		Event   Event  `json:"event"`
-		Message string `json:"message"`
+// NewClientFromEnv returns a new Client with the credentials read from the DIGITALOCEAN_CLIENT_ID and DIGITALOCEAN_API_KEY environment variables. API v2 tokens are not supported since the client authenticates against API v1.
+func NewClientFromEnv(opts ...Option) (*Client, error) {
+	var missing []string
+
+	clientID := os.Getenv(EnvClientID)
+	if clientID == "" {
+		missing = append(missing, EnvClientID)
 	}
 
-	err := c.doGet(fmt.Sprintf("/events/%d", ID), &DOResp)
-	if err != nil {
-		return nil, err
+	apiKey := os.Getenv(EnvAPIKey)
+	if apiKey == "" {
+		missing = append(missing, EnvAPIKey)
 	}
 
-	if DOResp.Status == StatusError {
-		return nil, fmt.Errorf("could not get event with ID %d: %v", ID, DOResp.Message)
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("environment variable(s) %s must be set", strings.Join(missing, " and "))
 	}
 
-	return &DOResp.Event, nil
+	return NewClient(append([]Option{WithCredentials(clientID, apiKey)}, opts...)...), nil
 }
 
-// GetAllRegions returns all available regions
-func (c *Client) GetAllRegions() ([]Region, error) {
-	var DOResp struct {
-		Status  Status   `json:"status"`
-		Regions []Region `json:"regions"`
-		Message string   `json:"message"`
+// WithContext returns a copy of the client whose requests are bound to ctx, so they are aborted when ctx is canceled or its deadline passes
+func (c *Client) WithContext(ctx context.Context) *Client {
+	cc := *c
+	cc.ctx = ctx
+	return &cc
+}
+
+func (c *Client) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
 	}
 
-	err := c.doGet("/regions", &DOResp)
+	return c.ctx
+}
+
+// GetEventByID returns information about an event by its ID
+func (c *Client) GetEventByID(ID int) (*Event, error) {
+	v, err := call[Event](c, fmt.Sprintf("/events/%d", ID), nil, "event", "get event with ID %d", ID)
 	if err != nil {
 		return nil, err
 	}
 
-	if DOResp.Status == StatusError {
-		return nil, fmt.Errorf("could not get regions: %v", DOResp.Message)
-	}
+	return &v, nil
+}
 
-	return DOResp.Regions, nil
+// GetAllRegions returns all available regions
+func (c *Client) GetAllRegions() ([]Region, error) {
+	return call[[]Region](c, "/regions", nil, "regions", "get regions")
 }
 
 // GetAllSizes returns all available sizes for a droplet
 func (c *Client) GetAllSizes() ([]Size, error) {
-	var DOResp struct {
-		Status  Status `json:"status"`
-		Sizes   []Size `json:"sizes"`
-		Message string `json:"message"`
+	return call[[]Size](c, "/sizes", nil, "sizes", "get sizes")
+}
+
+// buildURL returns the full URL for an endpoint. The params are escaped and combined with the credentials into the query string.
+func (c *Client) buildURL(endpoint string, params url.Values, cred Credentials) string {
+	q := url.Values{}
+	for k, v := range params {
+		q[k] = v
 	}
+	q.Set("client_id", cred.ClientID)
+	q.Set("api_key", cred.APIKey)
 
-	err := c.doGet("/sizes", &DOResp)
-	if err != nil {
-		return nil, err
+	base := c.BaseURL
+	if base == "" {
+		base = APIURL
 	}
 
-	if DOResp.Status == StatusError {
-		return nil, fmt.Errorf("could not get sizes: %v", DOResp.Message)
+	return strings.TrimSuffix(base, "/") + endpoint + "?" + q.Encode()
+}
+
+// pathID formats an ID which can be either integer or string as an escaped path segment. String IDs, e.g. domain names, come from callers, so a "/" or "?" in one must not change the endpoint or start the query string; formatting with %v used to let "example.com/destroy" or "example.com?x=" through unescaped.
+func pathID(ID interface{}) string {
+	return url.PathEscape(fmt.Sprint(ID))
+}
+
+func (c *Client) userAgent() string {
+	ua := "godo/" + Version
+	if c.UserAgent != "" {
+		ua += " " + c.UserAgent
 	}
 
-	return DOResp.Sizes, nil
+	return ua
 }
 
-func (c *Client) doGet(endpoint string, i interface{}) error {
-	url := fmt.Sprintf("%s%s", APIURL, endpoint)
+func (c *Client) concurrency() int {
+	if c.Concurrency > 0 {
+		return c.Concurrency
+	}
 
-	if !strings.Contains(url, "?") {
-		url += "?"
-	} else {
-		url += "&"
+	return DefaultConcurrency
+}
+
+func (c *Client) maxResponseSize() int64 {
+	if c.MaxResponseSize != 0 {
+		return c.MaxResponseSize
 	}
-	url += fmt.Sprintf("client_id=%s&api_key=%s", c.ClientID, c.APIKey)
 
-	resp, err := http.Get(url)
+	return DefaultMaxResponseSize
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+
+	return http.DefaultClient
+}
+
+func (c *Client) doGet(endpoint string, params url.Values, i interface{}) error {
+	return c.doRequest(http.MethodGet, endpoint, params, nil, i)
+}
+
+func (c *Client) doPost(endpoint string, params url.Values, body, i interface{}) error {
+	return c.doRequest(http.MethodPost, endpoint, params, body, i)
+}
+
+func (c *Client) doPut(endpoint string, params url.Values, body, i interface{}) error {
+	return c.doRequest(http.MethodPut, endpoint, params, body, i)
+}
+
+func (c *Client) doDelete(endpoint string, params url.Values, i interface{}) error {
+	return c.doRequest(http.MethodDelete, endpoint, params, nil, i)
+}
+
+// doRequest sends a request with body encoded as JSON, unless it is nil, and decodes the response into i
+func (c *Client) doRequest(method, endpoint string, params url.Values, body, i interface{}) error {
+	payload, err := encodeBody(body)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
-	body, err := ioutil.ReadAll(resp.Body)
+	return c.request(method, endpoint, params, payload, func(r io.Reader) error {
+		return json.NewDecoder(r).Decode(i)
+	})
+}
+
+// encodeBody encodes the body of a request as JSON. A nil body stays empty.
+func encodeBody(body interface{}) ([]byte, error) {
+	if body == nil {
+		return nil, nil
+	}
+
+	if raw, ok := body.(json.RawMessage); ok {
+		return raw, nil
+	}
+
+	payload, err := json.Marshal(body)
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("could not encode request body: %w", err)
+	}
+
+	return payload, nil
+}
+
+// request sends a request with the payload, if any, and passes the body of a successful response to decode as it is read. The body is only buffered if it has to be kept for the cache or for deduplicated requests.
+func (c *Client) request(method, endpoint string, params url.Values, payload []byte, decode func(io.Reader) error) error {
+	if err := c.checkReadOnly(method, endpoint); err != nil {
+		return err
+	}
+
+	if c.planCall(method, endpoint, params, payload) {
+		return decode(strings.NewReader(plannedResponse))
 	}
 
-	err = json.Unmarshal(body, i)
+	if c.cache != nil && isMutating(method, endpoint) {
+		// Invalidated even if the call fails, as it may have been carried out anyway
+		defer c.InvalidateCache(invalidatedBy(endpoint)...)
+	}
+
+	key, ttl := c.cache.cacheKey(method, endpoint, params)
+	if ttl > 0 {
+		if body, ok := c.cache.cache.Get(key); ok {
+			return decodeResponse(bytes.NewReader(body), decode)
+		}
+	}
+
+	dedup := method == http.MethodGet && c.flights != nil
+	if ttl <= 0 && !dedup {
+		return c.stream(method, endpoint, params, payload, func(r io.Reader) error {
+			return decodeResponse(r, decode)
+		})
+	}
+
+	var (
+		body []byte
+		err  error
+	)
+
+	if dedup {
+		shared := c.WithContext(context.WithoutCancel(c.context())).WithRequestTimeout(c.flightTimeout())
+		body, err = c.flights.do(c.context(), method+" "+redactedEndpoint(endpoint, params), func() ([]byte, error) {
+			return shared.fetch(method, endpoint, params, nil)
+		})
+		err = cancelError(c.context(), endpoint, err)
+	} else {
+		body, err = c.fetch(method, endpoint, params, payload)
+	}
 	if err != nil {
-		panic(err)
+		return err
+	}
+
+	if ttl > 0 {
+		c.cache.store(key, body, ttl)
+	}
+
+	return decodeResponse(bytes.NewReader(body), decode)
+}
+
+// decodeResponse passes a body to decode and wraps the error
+func decodeResponse(r io.Reader, decode func(io.Reader) error) error {
+	if err := decode(r); err != nil {
+		return fmt.Errorf("could not decode response: %w", err)
 	}
 
 	return nil
 }
+
+// fetch sends a request and returns the body of a successful response
+func (c *Client) fetch(method, endpoint string, params url.Values, payload []byte) (body []byte, err error) {
+	err = c.stream(method, endpoint, params, payload, func(r io.Reader) error {
+		body, err = io.ReadAll(r)
+		return err
+	})
+
+	return body, err
+}
+
+// stream sends a request and passes the body of a successful response to read
+func (c *Client) stream(method, endpoint string, params url.Values, payload []byte, read func(io.Reader) error) (err error) {
+	parent := c.context()
+	ctx, span := c.startSpan(parent, method, endpoint)
+
+	if c.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.requestTimeout)
+		defer cancel()
+	}
+
+	var status, size, sent, received int
+	start := time.Now()
+	defer func() {
+		err = cancelError(parent, endpoint, err)
+
+		d := time.Since(start)
+		family := endpointFamily(endpoint)
+		c.logRequest(ctx, method, endpoint, params, d, status, size, err)
+		c.traffic.add(family, sent, received)
+		if c.Metrics != nil {
+			c.Metrics.ObserveRequest(family, status, d, err)
+			if o, ok := c.Metrics.(TrafficObserver); ok {
+				o.ObserveTraffic(family, sent, received)
+			}
+		}
+		if span != nil {
+			span.End(status, err)
+		}
+	}()
+
+	var resp *http.Response
+	for attempt := 0; ; attempt++ {
+		if c.RateLimiter != nil {
+			if err := c.RateLimiter.Wait(ctx); err != nil {
+				return err
+			}
+		}
+
+		a := &sendAttempt{}
+		resp, err = c.send(ctx, a, method, endpoint, params, payload)
+		sent += a.sent
+
+		// Retrying is pointless if ctx would be done before the delay passes, so the last response is returned instead
+		delay := retryDelay(resp, attempt)
+		if attempt >= c.Retries || ctx.Err() != nil || !shouldRetry(resp, err, isMutating(method, endpoint)) || outlivesContext(ctx, delay) {
+			if err != nil {
+				return err
+			}
+			break
+		}
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+	defer resp.Body.Close()
+
+	status = resp.StatusCode
+	c.rate.update(resp.Header)
+
+	wire := &countingBody{ReadCloser: resp.Body}
+	resp.Body = wire
+	defer func() { received = wire.n }()
+
+	if err := serverTimeout(endpoint, resp); err != nil {
+		return err
+	}
+
+	r, err := decodedBody(resp)
+	if err != nil {
+		return err
+	}
+
+	if max := c.maxResponseSize(); max > 0 {
+		r = &limitedReader{r: io.LimitReader(r, max+1), max: max}
+	}
+
+	counter := &countingReader{r: r}
+	defer func() { size = counter.n }()
+
+	if resp.StatusCode == http.StatusNoContent || method == http.MethodHead {
+		return nil
+	}
+
+	body := bufio.NewReaderSize(counter, sniffSize)
+	if err := c.sniffJSON(endpoint, resp, body); err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var errResp struct {
+			Message string `json:"message"`
+		}
+		if json.NewDecoder(body).Decode(&errResp) != nil || errResp.Message == "" {
+			errResp.Message = resp.Status
+		}
+
+		return c.apiError(endpoint, resp.StatusCode, errResp.Message)
+	}
+
+	return read(body)
+}
+
+// limitedReader fails with ErrResponseTooLarge once more than max bytes have been read
+type limitedReader struct {
+	r   io.Reader
+	n   int64
+	max int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.n += int64(n)
+	if l.n > l.max {
+		return n, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, l.max)
+	}
+
+	return n, err
+}
+
+// countingReader counts the bytes read from r
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += n
+	return n, err
+}
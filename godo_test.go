@@ -0,0 +1,52 @@
+package godo
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestPathID(t *testing.T) {
+	tests := []struct {
+		ID   interface{}
+		want string
+	}{
+		{123, "123"},
+		{"example.com", "example.com"},
+		{"example.com/destroy", "example.com%2Fdestroy"},
+		{"example.com?api_key=x", "example.com%3Fapi_key=x"},
+		{"exa mple.com", "exa%20mple.com"},
+	}
+
+	for _, tt := range tests {
+		if got := pathID(tt.ID); got != tt.want {
+			t.Errorf("pathID(%v) = %q, want %q", tt.ID, got, tt.want)
+		}
+	}
+}
+
+func TestDomainIDEscapedInPath(t *testing.T) {
+	var (
+		path  string
+		query url.Values
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, query = r.URL.EscapedPath(), r.URL.Query()
+		fmt.Fprint(w, `{"status":"OK","records":[]}`)
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithCredentials("id", "key"), WithBaseURL(srv.URL))
+	if _, err := c.GetAllRecordsByDomain("example.com/destroy?name=x"); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "/domains/example.com%2Fdestroy%3Fname=x/records"; path != want {
+		t.Errorf("requested path %q, want %q", path, want)
+	}
+	if query.Has("name") {
+		t.Errorf("domain ID leaked into the query %q", query.Encode())
+	}
+}
@@ -0,0 +1,23 @@
+package godo
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// decodedBody returns a reader of the body of a response which decompresses it if the server gzipped it. Requests ask for gzip explicitly, so the HTTP client doesn't decompress responses itself.
+func decodedBody(resp *http.Response) (io.Reader, error) {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return resp.Body, nil
+	}
+
+	r, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not decompress response: %w", err)
+	}
+
+	return r, nil
+}
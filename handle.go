@@ -0,0 +1,118 @@
+package godo
+
+// DropletHandle is a droplet bound to the client it was fetched with, so actions can be invoked on it directly, e.g. d.Reboot() instead of c.RebootDroplet(d.ID). The actions return an event ID like the client methods they call.
+type DropletHandle struct {
+	Droplet
+	c *Client
+}
+
+// Handle binds a droplet to the client
+func (c *Client) Handle(d Droplet) *DropletHandle {
+	return &DropletHandle{Droplet: d, c: c}
+}
+
+// GetDropletHandle returns a droplet bound to the client
+func (c *Client) GetDropletHandle(ID int) (*DropletHandle, error) {
+	d, err := c.GetDropletByID(ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Handle(*d), nil
+}
+
+// Client returns the client the droplet is bound to
+func (d *DropletHandle) Client() *Client {
+	return d.c
+}
+
+// Refresh fetches the droplet again, e.g. to see the result of an action once its event has finished
+func (d *DropletHandle) Refresh() error {
+	droplet, err := d.c.GetDropletByID(d.ID)
+	if err != nil {
+		return err
+	}
+	d.Droplet = *droplet
+
+	return nil
+}
+
+// Reboot reboots the droplet, see RebootDroplet
+func (d *DropletHandle) Reboot() (int, error) {
+	return d.c.RebootDroplet(d.ID)
+}
+
+// PowerCycle power cycles the droplet, see PowerCycleDroplet
+func (d *DropletHandle) PowerCycle() (int, error) {
+	return d.c.PowerCycleDroplet(d.ID)
+}
+
+// ShutDown shuts the droplet down, see ShutDownDroplet
+func (d *DropletHandle) ShutDown() (int, error) {
+	return d.c.ShutDownDroplet(d.ID)
+}
+
+// PowerOff powers the droplet off, see PowerOffDroplet
+func (d *DropletHandle) PowerOff() (int, error) {
+	return d.c.PowerOffDroplet(d.ID)
+}
+
+// PowerOn powers the droplet on, see PowerOnDroplet
+func (d *DropletHandle) PowerOn() (int, error) {
+	return d.c.PowerOnDroplet(d.ID)
+}
+
+// ResetRootPass resets the root password of the droplet, see ResetRootPassDroplet
+func (d *DropletHandle) ResetRootPass() (int, error) {
+	return d.c.ResetRootPassDroplet(d.ID)
+}
+
+// Resize resizes the droplet to a size given by slug or ID, see ResizeDroplet
+func (d *DropletHandle) Resize(size interface{}) (int, error) {
+	return d.c.ResizeDroplet(d.ID, size)
+}
+
+// ResizeWithOptions resizes the droplet flexibly or permanently, see ResizeDropletWithOptions
+func (d *DropletHandle) ResizeWithOptions(size interface{}, opts ResizeOptions) (int, error) {
+	return d.c.ResizeDropletWithOptions(d.ID, size, opts)
+}
+
+// Snapshot takes a snapshot of the droplet, see TakeSnapshotOnDroplet
+func (d *DropletHandle) Snapshot(name string) (int, error) {
+	return d.c.TakeSnapshotOnDroplet(d.ID, name)
+}
+
+// Snapshots returns the snapshots of the droplet, see GetDropletSnapshots
+func (d *DropletHandle) Snapshots() ([]Snapshot, error) {
+	return d.c.GetDropletSnapshots(d.ID)
+}
+
+// Restore restores the droplet from an image, see RestoreDroplet
+func (d *DropletHandle) Restore(imageID int) (int, error) {
+	return d.c.RestoreDroplet(d.ID, imageID)
+}
+
+// Rebuild rebuilds the droplet from an image, see RebuildDroplet
+func (d *DropletHandle) Rebuild(imageID int) (int, error) {
+	return d.c.RebuildDroplet(d.ID, imageID)
+}
+
+// Rename renames the droplet, see RenameDroplet
+func (d *DropletHandle) Rename(name string) (int, error) {
+	return d.c.RenameDroplet(d.ID, name)
+}
+
+// EnableBackups enables automatic backups of the droplet, see EnableBackupsOnDroplet
+func (d *DropletHandle) EnableBackups() (int, error) {
+	return d.c.EnableBackupsOnDroplet(d.ID)
+}
+
+// DisableBackups disables automatic backups of the droplet, see DisableBackupsOnDroplet
+func (d *DropletHandle) DisableBackups() (int, error) {
+	return d.c.DisableBackupsOnDroplet(d.ID)
+}
+
+// Destroy destroys the droplet, see DeleteDropletByID
+func (d *DropletHandle) Destroy() (int, error) {
+	return d.c.DeleteDropletByID(d.ID)
+}
@@ -0,0 +1,351 @@
+package godo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type hclCodec struct{}
+
+func (hclCodec) Encode(w io.Writer, v interface{}) error {
+	tree, err := toTree(v)
+	if err != nil {
+		return err
+	}
+
+	obj, ok := tree.(object)
+	if !ok {
+		return fmt.Errorf("only objects can be encoded as HCL")
+	}
+
+	var buf bytes.Buffer
+	writeHCLBody(&buf, obj, 0)
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// writeHCLBody writes the attributes of an object, with non-empty objects as blocks
+func writeHCLBody(buf *bytes.Buffer, obj object, indent int) {
+	pad := strings.Repeat(" ", indent)
+
+	for _, f := range obj {
+		if o, ok := f.value.(object); ok && len(o) > 0 {
+			buf.WriteString(pad + hclKey(f.key) + " {\n")
+			writeHCLBody(buf, o, indent+2)
+			buf.WriteString(pad + "}\n")
+			continue
+		}
+
+		buf.WriteString(pad + hclKey(f.key) + " = ")
+		writeHCLValue(buf, f.value, indent)
+		buf.WriteString("\n")
+	}
+}
+
+func writeHCLValue(buf *bytes.Buffer, v interface{}, indent int) {
+	pad := strings.Repeat(" ", indent)
+
+	switch t := v.(type) {
+	case object:
+		if len(t) == 0 {
+			buf.WriteString("{}")
+			return
+		}
+
+		buf.WriteString("{\n")
+		writeHCLBody(buf, t, indent+2)
+		buf.WriteString(pad + "}")
+	case []interface{}:
+		scalars := true
+		for _, item := range t {
+			switch item.(type) {
+			case object, []interface{}:
+				scalars = false
+			}
+		}
+
+		if scalars {
+			items := make([]string, len(t))
+			for i, item := range t {
+				items[i] = hclScalar(item)
+			}
+
+			buf.WriteString("[" + strings.Join(items, ", ") + "]")
+			return
+		}
+
+		buf.WriteString("[\n")
+		for _, item := range t {
+			buf.WriteString(pad + "  ")
+			writeHCLValue(buf, item, indent+2)
+			buf.WriteString(",\n")
+		}
+		buf.WriteString(pad + "]")
+	default:
+		buf.WriteString(hclScalar(t))
+	}
+}
+
+func hclScalar(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(t)
+	case json.Number:
+		return t.String()
+	case string:
+		return strconv.Quote(t)
+	}
+
+	return fmt.Sprint(v)
+}
+
+// hclKey quotes a key which isn't an identifier
+func hclKey(key string) string {
+	if key == "" {
+		return `""`
+	}
+
+	for _, r := range key {
+		if !isHCLIdentRune(r) {
+			return strconv.Quote(key)
+		}
+	}
+
+	return key
+}
+
+func isHCLIdentRune(r rune) bool {
+	return r == '_' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// hclToken is a string, an identifier, a number or a punctuation character
+type hclToken struct {
+	line   int
+	text   string
+	quoted bool
+}
+
+type hclParser struct {
+	tokens []hclToken
+	pos    int
+}
+
+func (hclCodec) Decode(r io.Reader, v interface{}) error {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	tokens, err := lexHCL(string(src))
+	if err != nil {
+		return err
+	}
+
+	p := hclParser{tokens: tokens}
+
+	tree, err := p.parseBody(false)
+	if err != nil {
+		return err
+	}
+
+	return fromTree(tree, v)
+}
+
+func lexHCL(src string) ([]hclToken, error) {
+	var tokens []hclToken
+
+	line := 1
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r' || c == ',':
+			// Commas are optional separators
+			if c == ',' {
+				tokens = append(tokens, hclToken{line: line, text: ","})
+			}
+			i++
+		case c == '#' || strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.IndexByte("={}[]", c) >= 0:
+			tokens = append(tokens, hclToken{line: line, text: string(c)})
+			i++
+		case c == '"':
+			j := i + 1
+			for j < len(src) && src[j] != '"' {
+				if src[j] == '\\' {
+					j++
+				}
+				if j < len(src) && src[j] == '\n' {
+					return nil, fmt.Errorf("line %d: unterminated string", line)
+				}
+				j++
+			}
+			if j >= len(src) {
+				return nil, fmt.Errorf("line %d: unterminated string", line)
+			}
+
+			s, err := strconv.Unquote(src[i : j+1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid string %s", line, src[i:j+1])
+			}
+
+			tokens = append(tokens, hclToken{line: line, text: s, quoted: true})
+			i = j + 1
+		default:
+			j := i
+			for j < len(src) && (isHCLIdentRune(rune(src[j])) || src[j] == '.' || src[j] == '+') {
+				j++
+			}
+			if j == i {
+				return nil, fmt.Errorf("line %d: unexpected character %q", line, c)
+			}
+
+			tokens = append(tokens, hclToken{line: line, text: src[i:j]})
+			i = j
+		}
+	}
+
+	return tokens, nil
+}
+
+func (p *hclParser) next() (hclToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return hclToken{}, false
+	}
+
+	t := p.tokens[p.pos]
+	p.pos++
+	return t, true
+}
+
+func (p *hclParser) peek() (hclToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return hclToken{}, false
+	}
+
+	return p.tokens[p.pos], true
+}
+
+// isPunct reports whether t is the unquoted punctuation character s
+func (t hclToken) isPunct(s string) bool {
+	return !t.quoted && t.text == s
+}
+
+// parseBody parses "key = value" attributes and "key { ... }" blocks until the end of the input or, in a block, until "}"
+func (p *hclParser) parseBody(block bool) (interface{}, error) {
+	obj := object{}
+
+	for {
+		t, ok := p.next()
+		if !ok {
+			if block {
+				return nil, fmt.Errorf("unterminated block")
+			}
+
+			return obj, nil
+		}
+
+		switch {
+		case t.isPunct(","):
+			continue
+		case t.isPunct("}"):
+			if !block {
+				return nil, fmt.Errorf("line %d: unexpected }", t.line)
+			}
+
+			return obj, nil
+		case !t.quoted && strings.ContainsAny(t.text, "={}[]"):
+			return nil, fmt.Errorf("line %d: expected a key, got %s", t.line, t.text)
+		}
+
+		op, ok := p.next()
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected = or { after %s", t.line, t.text)
+		}
+
+		var (
+			value interface{}
+			err   error
+		)
+		switch {
+		case op.isPunct("="):
+			value, err = p.parseValue()
+		case op.isPunct("{"):
+			value, err = p.parseBody(true)
+		default:
+			return nil, fmt.Errorf("line %d: expected = or { after %s", op.line, t.text)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		obj = append(obj, field{t.text, value})
+	}
+}
+
+func (p *hclParser) parseValue() (interface{}, error) {
+	t, ok := p.next()
+	if !ok {
+		return nil, fmt.Errorf("unexpected end of input")
+	}
+
+	switch {
+	case t.quoted:
+		return t.text, nil
+	case t.isPunct("{"):
+		return p.parseBody(true)
+	case t.isPunct("["):
+		list := []interface{}{}
+		for {
+			next, ok := p.peek()
+			if !ok {
+				return nil, fmt.Errorf("line %d: unterminated list", t.line)
+			}
+
+			if next.isPunct(",") {
+				p.pos++
+				continue
+			}
+
+			if next.isPunct("]") {
+				p.pos++
+				return list, nil
+			}
+
+			v, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+
+			list = append(list, v)
+		}
+	}
+
+	switch t.text {
+	case "null":
+		return nil, nil
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+
+	if yamlNumber.MatchString(t.text) {
+		return json.Number(t.text), nil
+	}
+
+	return nil, fmt.Errorf("line %d: unexpected %s, strings must be quoted", t.line, t.text)
+}
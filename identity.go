@@ -0,0 +1,75 @@
+package godo
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// identityPattern matches an identity, which must be valid in hostnames
+	identityPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+	// expirySuffix matches the expiry suffix which may follow the identity in a droplet name
+	expirySuffix = regexp.MustCompile(`-exp-\d{8}t\d{4}z$`)
+)
+
+// WithIdentity returns name with a suffix encoding a logical identity, e.g. "web-id-web-3". Unlike the ID, the identity is kept by a droplet which replaces another, so orchestration can track "web-3" across replacements. The v1 API has no user data or tags, so the name is the only place to keep it.
+func WithIdentity(name, identity string) string {
+	return fmt.Sprintf("%s-id-%s", name, identity)
+}
+
+// ParseIdentity returns the identity encoded by WithIdentity in the name of a droplet whose name was base before. Names and identities may both contain "-id-" and droplet names allow no character which identities don't, so the identity can only be told apart from the name by the known base: "app-id-x-id-web" has the identity "x-id-web" with the base "app" and "web" with the base "app-id-x", and "my-id-server" has no identity unless its base is "my".
+func ParseIdentity(name, base string) (string, bool) {
+	rest, ok := strings.CutPrefix(name, base+"-id-")
+	if !ok || base == "" {
+		return "", false
+	}
+
+	// An identity never ends like an expiry suffix, see validIdentity
+	rest = expirySuffix.ReplaceAllString(rest, "")
+	if !identityPattern.MatchString(rest) {
+		return "", false
+	}
+
+	return rest, true
+}
+
+// validIdentity returns an error if an identity can't be encoded in a name
+func validIdentity(identity string) error {
+	if !identityPattern.MatchString(identity) {
+		return fmt.Errorf("identity %q must only contain lowercase letters, digits and inner dashes", identity)
+	}
+
+	if expirySuffix.MatchString(identity) {
+		return fmt.Errorf("identity %q must not end like an expiry suffix", identity)
+	}
+
+	return nil
+}
+
+// GetDropletByIdentity returns the droplet named base whose name encodes the identity, see ParseIdentity. The error matches ErrNotFound if there is none, or is an AmbiguousNameError if there are several, e.g. while a replacement is running alongside the droplet it replaces.
+func (c *Client) GetDropletByIdentity(ctx context.Context, base, identity string) (*Droplet, error) {
+	droplets, err := c.WithContext(ctx).GetAllDroplets()
+	if err != nil {
+		return nil, err
+	}
+
+	var found *Droplet
+	var ids []int
+	for i, d := range droplets {
+		if id, ok := ParseIdentity(d.Name, base); ok && id == identity {
+			found = &droplets[i]
+			ids = append(ids, d.ID)
+		}
+	}
+
+	switch {
+	case len(ids) > 1:
+		return nil, fmt.Errorf("could not get droplet with identity %q: %w", identity, &AmbiguousNameError{Name: identity, IDs: ids})
+	case found == nil:
+		return nil, fmt.Errorf("could not get droplet with identity %q: %w", identity, ErrNotFound)
+	}
+
+	return found, nil
+}
@@ -0,0 +1,48 @@
+package godo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseIdentity(t *testing.T) {
+	expires := time.Date(2014, 10, 16, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name, base string
+		want       string
+		ok         bool
+	}{
+		{WithIdentity("web", "web-3"), "web", "web-3", true},
+		{WithIdentity("app-id-x", "web"), "app-id-x", "web", true},
+		{WithIdentity("app-id-x", "web"), "app", "x-id-web", true},
+		{WithExpiry(WithIdentity("ci", "runner-1"), expires), "ci", "runner-1", true},
+		{"my-id-server", "web", "", false},
+		{"my-id-server", "", "", false},
+		{"web", "web", "", false},
+		{"web-id-", "web", "", false},
+		{"web-id-Web", "web", "", false},
+		{"webapp-id-web", "web", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseIdentity(tt.name, tt.base)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseIdentity(%q, %q) = %q, %v, want %q, %v", tt.name, tt.base, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestValidIdentity(t *testing.T) {
+	for _, identity := range []string{"web-3", "x-id-web"} {
+		if err := validIdentity(identity); err != nil {
+			t.Errorf("validIdentity(%q) = %v, want nil", identity, err)
+		}
+	}
+
+	for _, identity := range []string{"", "Web", "web-", "web_3", "web-exp-20141016t1500z"} {
+		if validIdentity(identity) == nil {
+			t.Errorf("validIdentity(%q) = nil, want an error", identity)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package godo
 
-import "fmt"
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
 
 // Image represents a Digitalocean image.
 type Image struct {
@@ -15,103 +19,55 @@ type Image struct {
 
 // DeleteImage deletes an image. There is no way to restore a deleted image so be careful and ensure any data is properly backed up.
 func (c *Client) DeleteImage(ID interface{}) error {
-	var DOResp struct {
-		Status  Status `json:"status"`
-		Message string `json:"message"`
-	}
-
 	var s string
 	switch ID.(type) {
 	case string, int:
-		s = fmt.Sprintf("/images/%v/destroy", ID)
+		s = fmt.Sprintf("/images/%s/destroy", pathID(ID))
 	default:
 		return fmt.Errorf("ID must be either a string or integer")
 	}
 
-	err := c.doGet(s, &DOResp)
-	if err != nil {
-		return err
-	}
-
-	if DOResp.Status == StatusError {
-		return fmt.Errorf("could not delete image with ID %v: %v", ID, DOResp.Message)
-	}
-
-	return nil
+	_, err := call[struct{}](c, s, nil, "", "delete image with ID %v", ID)
+	return err
 }
 
 // GetAllImages returns all available images for the client ID.
 func (c *Client) GetAllImages() ([]Image, error) {
-	var DOResp struct {
-		Status  Status  `json:"status"`
-		Images  []Image `json:"images"`
-		Message string  `json:"message"`
-	}
-
-	err := c.doGet("/images", &DOResp)
-	if err != nil {
-		return nil, err
-	}
-
-	if DOResp.Status == StatusError {
-		return nil, fmt.Errorf("could not get images: %v", DOResp.Message)
-	}
+	return list[Image](c, "/images", nil, "images", "get images")
+}
 
-	return DOResp.Images, nil
+// GetMyImages returns the images which belong to the client ID, i.e. its snapshots and backups
+func (c *Client) GetMyImages() ([]Image, error) {
+	return list[Image](c, "/images", url.Values{"filter": {"my_images"}}, "images", "get my images")
 }
 
 // GetImageByID returns information about an image by its ID, which can be either integer or string
 func (c *Client) GetImageByID(ID interface{}) (*Image, error) {
-	var DOResp struct {
-		Status  Status `json:"status"`
-		Image   Image  `json:"image"`
-		Message string `json:"message"`
-	}
-
 	var s string
 	switch ID.(type) {
 	case string, int:
-		s = fmt.Sprintf("/images/%v", ID)
+		s = fmt.Sprintf("/images/%s", pathID(ID))
 	default:
 		return nil, fmt.Errorf("ID must be either a string or integer")
 	}
 
-	err := c.doGet(s, &DOResp)
+	v, err := call[Image](c, s, nil, "image", "get image with ID %v", ID)
 	if err != nil {
 		return nil, err
 	}
 
-	if DOResp.Status == StatusError {
-		return nil, fmt.Errorf("could not get image with ID %v: %v", ID, DOResp.Message)
-	}
-
-	return &DOResp.Image, nil
+	return &v, nil
 }
 
 // TransferImage transfers an image to a specified region. Returns an event ID on success.
 func (c *Client) TransferImage(ID interface{}, regionID int) (int, error) {
-	var DOResp struct {
-		Status  Status `json:"status"`
-		EventID int    `json:"event_id"`
-		Message string `json:"message"`
-	}
-
 	var s string
 	switch ID.(type) {
 	case string, int:
-		s = fmt.Sprintf("/images/%v/transfer?region_id=%d", ID, regionID)
+		s = fmt.Sprintf("/images/%s/transfer", pathID(ID))
 	default:
 		return 0, fmt.Errorf("ID must be either a string or integer")
 	}
 
-	err := c.doGet(s, &DOResp)
-	if err != nil {
-		return 0, err
-	}
-
-	if DOResp.Status == StatusError {
-		return 0, fmt.Errorf("could not transfer image with ID %v: %v", ID, DOResp.Message)
-	}
-
-	return DOResp.EventID, nil
+	return call[int](c, s, url.Values{"region_id": {strconv.Itoa(regionID)}}, "event_id", "transfer image with ID %v", ID)
 }
@@ -0,0 +1,35 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Init validates the client's credentials and warms the slug caches of sizes, regions and images. Calling it is optional: a client initializes lazily, so the first calls are the expensive ones and authentication errors only show up then. Init lets e.g. serverless functions choose when that happens.
+func (c *Client) Init(ctx context.Context) error {
+	cc := c.WithContext(ctx)
+
+	catalogs := []struct {
+		kind  string
+		fetch func() (map[string]int, error)
+	}{
+		{"region", cc.regionIDs},
+		{"size", cc.sizeIDs},
+		{"image", cc.imageIDs},
+	}
+
+	for _, catalog := range catalogs {
+		ids, err := catalog.fetch()
+		if errors.Is(err, ErrUnauthorized) {
+			return fmt.Errorf("invalid credentials: %w", err)
+		}
+		if err != nil {
+			return err
+		}
+
+		c.slugs.set(catalog.kind, ids)
+	}
+
+	return nil
+}
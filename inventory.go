@@ -0,0 +1,120 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Inventory is a snapshot of the resources in an account at a point in time
+type Inventory struct {
+	TakenAt  time.Time
+	Droplets []Droplet
+	Domains  []Domain
+	// Records holds the records of each domain by domain ID
+	Records map[int][]DomainRecord
+	// Images holds the account's own images, i.e. snapshots and backups
+	Images []Image
+	Sizes  []Size
+
+	// Errors holds the errors of the fetches which failed, in which case the inventory is partial
+	Errors []error
+}
+
+// ExportInventory fetches all droplets, domains with their records, images and sizes of the account. The fetches run concurrently, bounded by the client's concurrency. If some of them fail, the partial inventory is returned together with an error joining their errors.
+func (c *Client) ExportInventory(ctx context.Context) (*Inventory, error) {
+	cc := c.WithContext(ctx)
+	inv := &Inventory{
+		TakenAt: time.Now(),
+		Records: make(map[int][]DomainRecord),
+	}
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, c.concurrency())
+	)
+
+	run := func(name string, fetch func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			err := fetch()
+			<-sem
+
+			if err != nil {
+				mu.Lock()
+				inv.Errors = append(inv.Errors, fmt.Errorf("%s: %w", name, err))
+				mu.Unlock()
+			}
+		}()
+	}
+
+	run("droplets", func() (err error) {
+		droplets, err := cc.GetAllDroplets()
+		mu.Lock()
+		inv.Droplets = droplets
+		mu.Unlock()
+		return err
+	})
+	run("images", func() (err error) {
+		images, err := cc.GetMyImages()
+		mu.Lock()
+		inv.Images = images
+		mu.Unlock()
+		return err
+	})
+	run("sizes", func() (err error) {
+		sizes, err := cc.GetAllSizes()
+		mu.Lock()
+		inv.Sizes = sizes
+		mu.Unlock()
+		return err
+	})
+	run("domains", func() error {
+		domains, err := cc.GetAllDomains()
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		inv.Domains = domains
+		mu.Unlock()
+
+		for _, d := range domains {
+			domainID := d.ID
+			run(fmt.Sprintf("records of domain %s", d.Name), func() error {
+				records, err := cc.GetAllRecordsByDomain(domainID)
+				mu.Lock()
+				inv.Records[domainID] = records
+				mu.Unlock()
+				return err
+			})
+		}
+
+		return nil
+	})
+
+	wg.Wait()
+
+	return inv, errors.Join(inv.Errors...)
+}
+
+// costPerHour returns the summed hourly cost of the droplets in the inventory
+func (inv *Inventory) costPerHour() float64 {
+	costs := make(map[int]float64, len(inv.Sizes))
+	for _, s := range inv.Sizes {
+		costs[s.ID] = s.CostPerHour
+	}
+
+	var total float64
+	for _, d := range inv.Droplets {
+		total += costs[d.SizeID]
+	}
+
+	return total
+}
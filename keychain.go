@@ -0,0 +1,74 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// DefaultKeychainService is the service name under which Keychain stores API keys by default
+const DefaultKeychainService = "godo"
+
+// ErrCredentialsNotFound is returned by a CredentialProvider which has no credentials stored
+var ErrCredentialsNotFound = errors.New("credentials not found")
+
+// CredentialProvider supplies the credentials of a client, e.g. from a secret store
+type CredentialProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// Keychain is a CredentialProvider backed by the credential store of the OS: the Keychain on macOS, the Credential Manager on Windows and the Secret Service on Linux, via the secret-tool command. The API key is stored under Service with the client ID as the account name.
+type Keychain struct {
+	Service  string
+	ClientID string
+}
+
+// NewKeychain returns a Keychain for the API key of clientID under DefaultKeychainService
+func NewKeychain(clientID string) *Keychain {
+	return &Keychain{Service: DefaultKeychainService, ClientID: clientID}
+}
+
+func (k *Keychain) service() string {
+	if k.Service != "" {
+		return k.Service
+	}
+
+	return DefaultKeychainService
+}
+
+// Credentials reads the API key from the credential store. It returns ErrCredentialsNotFound if none is stored.
+func (k *Keychain) Credentials(ctx context.Context) (Credentials, error) {
+	if k.ClientID == "" {
+		return Credentials{}, fmt.Errorf("client ID must be set")
+	}
+
+	apiKey, err := keychainLookup(ctx, k.service(), k.ClientID)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("could not read API key from keychain: %w", err)
+	}
+
+	return Credentials{ClientID: k.ClientID, APIKey: apiKey}, nil
+}
+
+// Store saves the API key in the credential store, replacing the stored one
+func (k *Keychain) Store(ctx context.Context, apiKey string) error {
+	if k.ClientID == "" {
+		return fmt.Errorf("client ID must be set")
+	}
+
+	if err := keychainStore(ctx, k.service(), k.ClientID, apiKey); err != nil {
+		return fmt.Errorf("could not store API key in keychain: %w", err)
+	}
+
+	return nil
+}
+
+// NewClientFromProvider returns a new Client with the credentials supplied by p
+func NewClientFromProvider(ctx context.Context, p CredentialProvider, opts ...Option) (*Client, error) {
+	cred, err := p.Credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClient(append([]Option{WithCredentials(cred.ClientID, cred.APIKey)}, opts...)...), nil
+}
@@ -0,0 +1,52 @@
+package godo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+// securityNotFound is the exit code of the security command for a missing item
+const securityNotFound = 44
+
+func keychainLookup(ctx context.Context, service, account string) (string, error) {
+	out, err := exec.CommandContext(ctx, "security", "find-generic-password", "-s", service, "-a", account, "-w").Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == securityNotFound {
+			return "", ErrCredentialsNotFound
+		}
+
+		return "", err
+	}
+
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+func keychainStore(ctx context.Context, service, account, secret string) error {
+	if strings.ContainsAny(service+account+secret, "\r\n") {
+		return errors.New("service, account and secret must not contain line breaks")
+	}
+
+	var stderr bytes.Buffer
+
+	// The command is passed on stdin of the interactive mode, since the secret would show up in the process list as an argument
+	cmd := exec.CommandContext(ctx, "security", "-i")
+	cmd.Stdin = strings.NewReader("add-generic-password -U -s " + securityQuote(service) + " -a " + securityQuote(account) + " -w " + securityQuote(secret) + "\n")
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+
+	// The interactive mode reports failed commands on stderr without failing itself
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return errors.New(msg)
+	}
+
+	return err
+}
+
+// securityQuote quotes an argument of a command of the interactive mode of security
+func securityQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,42 @@
+package godo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+func keychainLookup(ctx context.Context, service, account string) (string, error) {
+	out, err := exec.CommandContext(ctx, "secret-tool", "lookup", "service", service, "account", account).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) == 0 {
+			// secret-tool fails silently if there is no matching secret
+			return "", ErrCredentialsNotFound
+		}
+
+		return "", err
+	}
+
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+func keychainStore(ctx context.Context, service, account, secret string) error {
+	var stderr bytes.Buffer
+
+	// secret-tool reads the secret from stdin so it doesn't show up in the process list
+	cmd := exec.CommandContext(ctx, "secret-tool", "store", "--label=DigitalOcean API key ("+service+")", "service", service, "account", account)
+	cmd.Stdin = strings.NewReader(secret)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return errors.New(msg)
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+//go:build !darwin && !linux && !windows
+
+package godo
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+)
+
+func keychainLookup(ctx context.Context, service, account string) (string, error) {
+	return "", fmt.Errorf("no credential store is supported on %s", runtime.GOOS)
+}
+
+func keychainStore(ctx context.Context, service, account, secret string) error {
+	return fmt.Errorf("no credential store is supported on %s", runtime.GOOS)
+}
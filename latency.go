@@ -0,0 +1,83 @@
+package godo
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// latencySamples is the number of connections made to each region, the fastest one is used
+const latencySamples = 3
+
+// RegionSpeedtestHost returns the host of the speedtest endpoint which represents the region with the given slug
+func RegionSpeedtestHost(slug string) string {
+	return fmt.Sprintf("speedtest-%s.digitalocean.com", slug)
+}
+
+// MeasureRegionLatency measures the TCP connect latency from the caller to each available region and returns it by region slug. Regions which could not be reached are left out.
+func (c *Client) MeasureRegionLatency(ctx context.Context) (map[string]time.Duration, error) {
+	regions, err := c.WithContext(ctx).GetAllRegions()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		latencies = make(map[string]time.Duration)
+	)
+
+	for _, r := range regions {
+		wg.Add(1)
+		go func(slug string) {
+			defer wg.Done()
+
+			d, err := measureLatency(ctx, RegionSpeedtestHost(slug))
+			if err != nil {
+				return
+			}
+
+			mu.Lock()
+			latencies[slug] = d
+			mu.Unlock()
+		}(r.Slug)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return latencies, nil
+}
+
+func measureLatency(ctx context.Context, host string) (time.Duration, error) {
+	var (
+		d       net.Dialer
+		best    time.Duration
+		lastErr error
+	)
+
+	for i := 0; i < latencySamples; i++ {
+		start := time.Now()
+		conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(host, "80"))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		elapsed := time.Since(start)
+		conn.Close()
+
+		if best == 0 || elapsed < best {
+			best = elapsed
+		}
+	}
+
+	if best == 0 {
+		return 0, lastErr
+	}
+
+	return best, nil
+}
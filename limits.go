@@ -0,0 +1,87 @@
+package godo
+
+import "context"
+
+// knownFeatures lists the optional capabilities whose availability GetLimits reports
+var knownFeatures = []Feature{FeaturePrivateNetworking, FeatureBackups}
+
+// RegionLimits is what can be created in a region
+type RegionLimits struct {
+	Region Region
+	// Sizes are the slugs of the sizes available in the region
+	Sizes []string
+	// Features are the optional capabilities offered in the region for the account
+	Features []Feature
+}
+
+// Limits gathers what constrains the resources an account can create, so callers can check them in one place before provisioning
+type Limits struct {
+	// Droplets is the number of droplets of the account
+	Droplets int
+	Regions  []RegionLimits
+	// Unsupported lists the optional capabilities the API refused for the account
+	Unsupported []Feature
+	// Rate is the rate-limit state after gathering the limits
+	Rate Rate
+}
+
+// Region returns the limits of a region by slug, or nil if it is not available
+func (l *Limits) Region(slug string) *RegionLimits {
+	for i := range l.Regions {
+		if l.Regions[i].Region.Slug == slug {
+			return &l.Regions[i]
+		}
+	}
+
+	return nil
+}
+
+// GetLimits returns the number of droplets, the sizes and features available per region, the features refused for the account and the rate-limit state. The v1 API reports neither the droplet limit of the account nor volumes and floating IPs, so they are not covered.
+func (c *Client) GetLimits(ctx context.Context) (*Limits, error) {
+	cc := c.WithContext(ctx)
+
+	droplets, err := cc.GetAllDroplets()
+	if err != nil {
+		return nil, err
+	}
+
+	regions, err := cc.GetAllRegions()
+	if err != nil {
+		return nil, err
+	}
+
+	sizes, err := cc.GetAllSizes()
+	if err != nil {
+		return nil, err
+	}
+
+	limits := &Limits{Droplets: len(droplets)}
+
+	for _, f := range knownFeatures {
+		if !c.Supports(f) {
+			limits.Unsupported = append(limits.Unsupported, f)
+		}
+	}
+
+	for _, r := range regions {
+		rl := RegionLimits{Region: r}
+
+		for _, s := range sizes {
+			if r.hasSize(s.Slug) {
+				rl.Sizes = append(rl.Sizes, s.Slug)
+			}
+		}
+
+		for _, f := range knownFeatures {
+			if r.hasFeature(f) && c.features.checkRegion(f, r) == nil {
+				rl.Features = append(rl.Features, f)
+			}
+		}
+
+		limits.Regions = append(limits.Regions, rl)
+	}
+
+	limits.Rate = c.RateLimit()
+
+	return limits, nil
+}
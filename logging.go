@@ -0,0 +1,66 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/url"
+	"time"
+)
+
+// WithLogger logs every API call to l. Successful calls are logged at level, failed ones at slog.LevelError.
+func WithLogger(l *slog.Logger, level slog.Level) Option {
+	return func(c *Client) {
+		c.Logger = l
+		c.LogLevel = level
+	}
+}
+
+// redactedEndpoint returns the endpoint with its query string, leaving out any credentials
+func redactedEndpoint(endpoint string, params url.Values) string {
+	q := url.Values{}
+	for k, v := range params {
+		if k == "client_id" || k == "api_key" {
+			continue
+		}
+		q[k] = v
+	}
+
+	if len(q) == 0 {
+		return endpoint
+	}
+
+	return endpoint + "?" + q.Encode()
+}
+
+// redactURLError replaces the URL of a *url.Error in err, which holds the credentials of the request, with the redacted endpoint. The error is redacted where it is returned by the HTTP client, so that neither the log, the tracer, the metrics nor the caller ever see the credentials.
+func redactURLError(err error, endpoint string, params url.Values) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL = redactedEndpoint(endpoint, params)
+	}
+
+	return err
+}
+
+func (c *Client) logRequest(ctx context.Context, method, endpoint string, params url.Values, d time.Duration, status, size int, err error) {
+	if c.Logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", method),
+		slog.String("endpoint", redactedEndpoint(endpoint, params)),
+		slog.Duration("duration", d),
+		slog.Int("status", status),
+		slog.Int("size", size),
+	}
+
+	level := c.LogLevel
+	if err != nil {
+		level = slog.LevelError
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	c.Logger.LogAttrs(ctx, level, "godo: API call", attrs...)
+}
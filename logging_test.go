@@ -0,0 +1,48 @@
+package godo
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// failingTransport fails every request as a network error would
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+// newFailingClient returns a client whose requests fail before reaching the server, with the API key "SECRETKEY"
+func newFailingClient(opts ...Option) *Client {
+	opts = append([]Option{
+		WithCredentials("CLIENTID", "SECRETKEY"),
+		WithHTTPClient(&http.Client{Transport: failingTransport{}}),
+		WithRetries(0),
+	}, opts...)
+
+	return NewClient(opts...)
+}
+
+func TestTransportErrorsDontLeakCredentials(t *testing.T) {
+	var logged bytes.Buffer
+	c := newFailingClient(WithLogger(slog.New(slog.NewTextHandler(&logged, nil)), slog.LevelInfo))
+
+	_, err := c.GetDropletByID(123)
+	if err == nil {
+		t.Fatal("request through a failing transport succeeded")
+	}
+
+	if strings.Contains(err.Error(), "SECRETKEY") {
+		t.Errorf("returned error contains the API key: %v", err)
+	}
+	if strings.Contains(logged.String(), "SECRETKEY") {
+		t.Errorf("log contains the API key: %s", logged.String())
+	}
+	if !strings.Contains(logged.String(), "connection refused") {
+		t.Errorf("log doesn't contain the transport error: %s", logged.String())
+	}
+}
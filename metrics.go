@@ -0,0 +1,50 @@
+package godo
+
+import (
+	"strings"
+	"time"
+)
+
+// MetricsCollector receives a measurement for every API call, e.g. to export them as Prometheus counters and histograms. It must be safe for concurrent use.
+type MetricsCollector interface {
+	// ObserveRequest is called after each API call. The endpoint has IDs replaced by "{id}" to keep the number of distinct values low, status is 0 if no response was received.
+	ObserveRequest(endpoint string, status int, duration time.Duration, err error)
+}
+
+// WithMetrics reports every API call to m
+func WithMetrics(m MetricsCollector) Option {
+	return func(c *Client) {
+		c.Metrics = m
+	}
+}
+
+// endpointFamily replaces the IDs in an endpoint by "{id}", e.g. "/droplets/123/reboot" becomes "/droplets/{id}/reboot"
+func endpointFamily(endpoint string) string {
+	segments := strings.Split(endpoint, "/")
+	for i, s := range segments {
+		if isIDSegment(s) {
+			segments[i] = "{id}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// isIDSegment reports whether a path segment is a numeric ID or a domain name
+func isIDSegment(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	if strings.Contains(s, ".") {
+		return true
+	}
+
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
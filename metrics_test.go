@@ -0,0 +1,33 @@
+package godo
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type recordingMetrics struct {
+	errs []string
+}
+
+func (m *recordingMetrics) ObserveRequest(endpoint string, status int, duration time.Duration, err error) {
+	if err != nil {
+		m.errs = append(m.errs, err.Error())
+	}
+}
+
+func TestObservedErrorsDontLeakCredentials(t *testing.T) {
+	m := &recordingMetrics{}
+	c := newFailingClient(WithMetrics(m))
+
+	if _, err := c.GetDropletByID(123); err == nil {
+		t.Fatal("request through a failing transport succeeded")
+	}
+
+	if len(m.errs) != 1 {
+		t.Fatalf("observed %d errors, want 1", len(m.errs))
+	}
+	if strings.Contains(m.errs[0], "SECRETKEY") {
+		t.Errorf("observed error contains the API key: %s", m.errs[0])
+	}
+}
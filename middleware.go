@@ -0,0 +1,26 @@
+package godo
+
+import "net/http"
+
+// RoundTripFunc sends a request to the API and returns its response
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps the sending of requests, e.g. for logging, credential rotation, caching or custom headers. It must call next to pass the request on.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// WithMiddleware appends middleware to the chain every request passes through
+func WithMiddleware(m ...Middleware) Option {
+	return func(c *Client) {
+		c.Middleware = append(c.Middleware, m...)
+	}
+}
+
+// roundTrip returns the middleware chain ending with the HTTP client. The first registered middleware is the outermost.
+func (c *Client) roundTrip() RoundTripFunc {
+	rt := RoundTripFunc(c.httpClient().Do)
+	for i := len(c.Middleware) - 1; i >= 0; i-- {
+		rt = c.Middleware[i](rt)
+	}
+
+	return rt
+}
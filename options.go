@@ -0,0 +1,99 @@
+package godo
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option configures a Client created by NewClient
+type Option func(*Client)
+
+// WithCredentials sets the client ID and API key used to authenticate against the API
+func WithCredentials(clientID, apiKey string) Option {
+	return func(c *Client) {
+		c.ClientID = clientID
+		c.APIKey = apiKey
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to send requests
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.HTTPClient = hc
+	}
+}
+
+// WithBaseURL sets the URL of the API, e.g. to point the client at a mock server or a proxy
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.BaseURL = baseURL
+	}
+}
+
+// WithTimeout sets the timeout of each request, including reading the response body. It defaults to DefaultTimeout.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		hc := http.Client{}
+		if c.HTTPClient != nil {
+			hc = *c.HTTPClient
+		}
+		hc.Timeout = d
+		c.HTTPClient = &hc
+	}
+}
+
+// WithOperationTimeout bounds how long high-level operations, e.g. waiting for a droplet to become active, may wait
+func WithOperationTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.OperationTimeout = d
+	}
+}
+
+// WithConcurrency bounds how many requests helpers which fan out send at once
+func WithConcurrency(n int) Option {
+	return func(c *Client) {
+		c.Concurrency = n
+	}
+}
+
+// WithRetries sets how many times a request is retried after a network error or a server error response. Mutating requests, e.g. creating a droplet, are only retried when they were rate limited or never reached the server, so they aren't carried out twice.
+func WithRetries(n int) Option {
+	return func(c *Client) {
+		c.Retries = n
+	}
+}
+
+// WithRateLimit throttles requests to rps requests per second with bursts of up to burst requests. It panics for invalid arguments, see NewRateLimiter.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(c *Client) {
+		c.RateLimiter = NewRateLimiter(rps, burst)
+	}
+}
+
+// WithPollInterval sets how often events and droplets are polled while waiting for them
+func WithPollInterval(d time.Duration) Option {
+	return func(c *Client) {
+		c.PollInterval = d
+	}
+}
+
+// WithUserAgent appends ua to the User-Agent header sent with every request, e.g. to identify the application using this package
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.UserAgent = ua
+	}
+}
+
+// WithMaxResponseSize sets the size in bytes of the largest response body the client reads, so a misbehaving endpoint or proxy can't exhaust memory. A negative size means no limit.
+func WithMaxResponseSize(n int64) Option {
+	return func(c *Client) {
+		c.MaxResponseSize = n
+	}
+}
+
+// WithRegionFallback makes CreateDroplet try the regions with the given slugs in order when the API reports that the requested region lacks capacity or is unavailable
+func WithRegionFallback(regions []string) Option {
+	return func(c *Client) {
+		c.RegionFallback = regions
+	}
+}
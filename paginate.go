@@ -0,0 +1,316 @@
+package godo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+)
+
+// PerPage is the number of items requested per page by the list methods
+const PerPage = 100
+
+// WithMaxListItems caps the number of items the list methods fetch, 0 means no limit
+func WithMaxListItems(n int) Option {
+	return func(c *Client) {
+		c.MaxListItems = n
+	}
+}
+
+// pageParams returns the query parameters requesting a page
+func pageParams(params url.Values, page int) url.Values {
+	q := url.Values{}
+	for k, v := range params {
+		q[k] = v
+	}
+	q.Set("page", strconv.Itoa(page))
+	q.Set("per_page", strconv.Itoa(PerPage))
+
+	return q
+}
+
+// pageLinks are the links of a response to the pages of a list endpoint
+type pageLinks struct {
+	Pages struct {
+		Next string `json:"next"`
+		Last string `json:"last"`
+	} `json:"pages"`
+}
+
+// hasNext reports whether the links point to a following page
+func (l pageLinks) hasNext() bool {
+	return l.Pages.Next != ""
+}
+
+// lastPage returns the number of the last page, or 0 if the links don't tell
+func (l pageLinks) lastPage() int {
+	u, err := url.Parse(l.Pages.Last)
+	if err != nil {
+		return 0
+	}
+
+	n, _ := strconv.Atoi(u.Query().Get("page"))
+	return n
+}
+
+// getPage sends a GET request for a page of a list endpoint and returns the items of the field key and the links to the other pages. The items are decoded one by one as the response is read, so a large page isn't held in memory twice.
+func getPage[T any](c *Client, endpoint string, params url.Values, key string, format string, args ...interface{}) ([]T, pageLinks, error) {
+	var (
+		env   envelope
+		items []T
+		links pageLinks
+	)
+
+	err := c.request(http.MethodGet, endpoint, params, nil, func(r io.Reader) (err error) {
+		items, links, err = decodePage[T](r, &env, key)
+		return err
+	})
+	if err != nil {
+		return nil, pageLinks{}, err
+	}
+
+	if err := c.checkEnvelope(endpoint, env, format, args...); err != nil {
+		return nil, pageLinks{}, err
+	}
+
+	return items, links, nil
+}
+
+// decodePage reads a page of a list endpoint and returns the items of the field key and the links to the other pages
+func decodePage[T any](r io.Reader, env *envelope, key string) ([]T, pageLinks, error) {
+	var (
+		links pageLinks
+		items []T
+	)
+
+	err := decodeEnvelope(r, env, func(dec *json.Decoder, name string) error {
+		switch name {
+		case "links":
+			return dec.Decode(&links)
+		case key:
+			return decodeArray(dec, func() error {
+				var item T
+				if err := dec.Decode(&item); err != nil {
+					return err
+				}
+
+				items = append(items, item)
+				return nil
+			})
+		}
+
+		return skipValue(dec)
+	})
+	if err != nil {
+		return nil, pageLinks{}, err
+	}
+
+	return items, links, nil
+}
+
+// decodeObject reads a JSON object and calls fn with each key, which must decode the value
+func decodeObject(dec *json.Decoder, fn func(key string) error) error {
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		if err := fn(tok.(string)); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, '}')
+}
+
+// decodeArray reads a JSON array, or null, and calls fn for each item, which must decode it
+func decodeArray(dec *json.Decoder, fn func() error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if tok == nil {
+		return nil
+	}
+
+	if tok != json.Delim('[') {
+		return fmt.Errorf("expected an array, got %v", tok)
+	}
+
+	for dec.More() {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, ']')
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if tok != delim {
+		return fmt.Errorf("expected %v, got %v", delim, tok)
+	}
+
+	return nil
+}
+
+// iterate walks the pages of a list endpoint and yields the items of the field key of each page, up to the client's MaxListItems. Pages are only fetched as the items are consumed. An error is yielded once with the zero value, ending the iteration.
+func iterate[T any](c *Client, endpoint string, params url.Values, key string, format string, args ...interface{}) iter.Seq2[T, error] {
+	return iterateFrom[T](c, 1, endpoint, params, key, format, args...)
+}
+
+// iterateFrom is like iterate but starts at the given page
+func iterateFrom[T any](c *Client, first int, endpoint string, params url.Values, key string, format string, args ...interface{}) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		n := 0
+
+		for page := first; ; page++ {
+			items, links, err := getPage[T](c, endpoint, pageParams(params, page), key, format, args...)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if c.MaxListItems > 0 && n >= c.MaxListItems {
+					return
+				}
+				n++
+
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if len(items) == 0 || !links.hasNext() {
+				return
+			}
+		}
+	}
+}
+
+// list returns the items of all pages of a list endpoint, see iterate
+func list[T any](c *Client, endpoint string, params url.Values, key string, format string, args ...interface{}) ([]T, error) {
+	var all []T
+	for item, err := range iterate[T](c, endpoint, params, key, format, args...) {
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, item)
+	}
+
+	return all, nil
+}
+
+// listConcurrent returns the items of all pages of a list endpoint like list, but fetches the pages after the first concurrently with the client's concurrency, merging the items in page order. Only the first page is fetched if it is the only one. If the first page doesn't link to the last one, the number of pages is unknown and the others are fetched one by one.
+func listConcurrent[T any](ctx context.Context, c *Client, endpoint string, params url.Values, key string, format string, args ...interface{}) ([]T, error) {
+	cc := c.WithContext(ctx)
+
+	all, links, err := getPage[T](cc, endpoint, pageParams(params, 1), key, format, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(all) == 0 || !links.hasNext() || c.MaxListItems > 0 && len(all) >= c.MaxListItems {
+		return capItems(c, all), nil
+	}
+
+	last := links.lastPage()
+	if last < 2 {
+		for item, err := range iterateFrom[T](cc, 2, endpoint, params, key, format, args...) {
+			if err != nil {
+				return nil, err
+			}
+			all = append(all, item)
+		}
+
+		return capItems(c, all), nil
+	}
+
+	if c.MaxListItems > 0 {
+		// Pages beyond the cap aren't needed
+		last = min(last, (c.MaxListItems+PerPage-1)/PerPage)
+	}
+
+	type result struct {
+		items []T
+		err   error
+	}
+
+	var (
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, c.concurrency())
+		results = make([]result, last-1)
+	)
+
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			items, _, err := getPage[T](cc, endpoint, pageParams(params, i+2), key, format, args...)
+			results[i] = result{items, err}
+		}()
+	}
+
+	wg.Wait()
+
+	for _, r := range results {
+		if r.err != nil {
+			return nil, r.err
+		}
+
+		all = append(all, r.items...)
+	}
+
+	return capItems(c, all), nil
+}
+
+// capItems returns the first items up to the client's MaxListItems
+func capItems[T any](c *Client, items []T) []T {
+	if c.MaxListItems > 0 && len(items) > c.MaxListItems {
+		return items[:c.MaxListItems]
+	}
+
+	return items
+}
+
+// Droplets iterates over all droplets, fetching them page by page
+func (c *Client) Droplets(ctx context.Context) iter.Seq2[Droplet, error] {
+	return iterate[Droplet](c.WithContext(ctx), "/droplets", nil, "droplets", "get droplets")
+}
+
+// Images iterates over all available images, fetching them page by page
+func (c *Client) Images(ctx context.Context) iter.Seq2[Image, error] {
+	return iterate[Image](c.WithContext(ctx), "/images", nil, "images", "get images")
+}
+
+// Domains iterates over all domains, fetching them page by page
+func (c *Client) Domains(ctx context.Context) iter.Seq2[Domain, error] {
+	return iterate[Domain](c.WithContext(ctx), "/domains", nil, "domains", "get domains")
+}
+
+// Records iterates over all records of a domain, fetching them page by page. The domainID can be integer or string
+func (c *Client) Records(ctx context.Context, domainID interface{}) iter.Seq2[DomainRecord, error] {
+	return iterate[DomainRecord](c.WithContext(ctx), fmt.Sprintf("/domains/%s/records", pathID(domainID)), nil, "records", "get records for domain %v", domainID)
+}
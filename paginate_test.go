@@ -0,0 +1,143 @@
+package godo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+// largeDropletList returns a /droplets response listing n droplets
+func largeDropletList(n int) []byte {
+	var b bytes.Buffer
+	b.WriteString(`{"status":"OK","droplets":[`)
+	for i := range n {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `{"id":%d,"name":"web-%d","image_id":3101045,"size_id":66,"region_id":4,"backups_active":false,"ip_address":"192.0.2.%d","private_ip_address":null,"locked":false,"status":"active","created_at":"2014-10-16T15:04:05Z"}`, i, i, i%256)
+	}
+	b.WriteString(`]}`)
+
+	return b.Bytes()
+}
+
+// BenchmarkDecodeDropletsBuffered decodes a large droplet list the way responses were decoded before they were streamed: the body is read whole, split into its fields and the list is decoded from its field
+func BenchmarkDecodeDropletsBuffered(b *testing.B) {
+	body := largeDropletList(5000)
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+
+	for b.Loop() {
+		data, err := io.ReadAll(bytes.NewReader(body))
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		var raw map[string]json.RawMessage
+		if err := json.Unmarshal(data, &raw); err != nil {
+			b.Fatal(err)
+		}
+
+		var droplets []Droplet
+		if err := json.Unmarshal(raw["droplets"], &droplets); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkDecodeDropletsStreamed decodes a large droplet list item by item as it is read, as the list methods do
+func BenchmarkDecodeDropletsStreamed(b *testing.B) {
+	body := largeDropletList(5000)
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+
+	for b.Loop() {
+		var env envelope
+		if _, _, err := decodePage[Droplet](bytes.NewReader(body), &env, "droplets"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkGetAllDroplets lists a large droplet list through a client, including the HTTP round trip
+func BenchmarkGetAllDroplets(b *testing.B) {
+	body := largeDropletList(5000)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(body)
+	}))
+	defer srv.Close()
+
+	c := NewClient(WithCredentials("id", "key"), WithBaseURL(srv.URL))
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+
+	for b.Loop() {
+		droplets, err := c.GetAllDroplets()
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(droplets) != 5000 {
+			b.Fatalf("got %d droplets, want 5000", len(droplets))
+		}
+	}
+}
+
+func TestListConcurrentRequests(t *testing.T) {
+	tests := []struct {
+		name  string
+		pages int
+		// linkLast is whether the pages link to the last one
+		linkLast bool
+	}{
+		{"single page", 1, true},
+		{"pages with last link", 7, true},
+		{"pages without last link", 3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int64
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests.Add(1)
+
+				page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+				links := `{"pages":{}}`
+				if page < tt.pages {
+					last := ""
+					if tt.linkLast {
+						last = fmt.Sprintf("/droplets?page=%d", tt.pages)
+					}
+					links = fmt.Sprintf(`{"pages":{"next":"/droplets?page=%d","last":%q}}`, page+1, last)
+				}
+				fmt.Fprintf(w, `{"status":"OK","droplets":[{"id":%d},{"id":%d}],"links":%s}`, page*10, page*10+1, links)
+			}))
+			defer srv.Close()
+
+			c := NewClient(WithCredentials("id", "key"), WithBaseURL(srv.URL), WithConcurrency(3))
+			droplets, err := c.GetAllDropletsConcurrently(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := requests.Load(); got != int64(tt.pages) {
+				t.Errorf("sent %d requests, want %d", got, tt.pages)
+			}
+
+			if len(droplets) != 2*tt.pages {
+				t.Fatalf("got %d droplets, want %d", len(droplets), 2*tt.pages)
+			}
+			for i, d := range droplets {
+				if want := (i/2+1)*10 + i%2; d.ID != want {
+					t.Errorf("droplet %d has ID %d, want %d", i, d.ID, want)
+				}
+			}
+		})
+	}
+}
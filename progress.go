@@ -0,0 +1,151 @@
+package godo
+
+import (
+	"fmt"
+	"sync"
+)
+
+// OperationProgress is the progress of one operation tracked by a Progress
+type OperationProgress struct {
+	// Key identifies the operation, e.g. "event 123" or "workflow clone"
+	Key string
+	// Detail describes the current stage, e.g. the running step of a workflow
+	Detail     string
+	Percentage float64
+	Done       bool
+	// Err is the error the operation failed with, if any
+	Err error
+}
+
+// ProgressSnapshot is the state of all operations tracked by a Progress at one point
+type ProgressSnapshot struct {
+	// Seq increases with every report, so a snapshot can be told apart from an older one
+	Seq uint64
+	// Operations are ordered by when they were first reported
+	Operations []OperationProgress
+	// Total is the number of operations, Done the number of finished ones, including failed ones
+	Total int
+	Done  int
+	// Failed is the number of operations which finished with an error
+	Failed int
+	// Percentage is the mean percentage of the operations
+	Percentage float64
+}
+
+// Progress merges the progress of concurrent operations into one stream of snapshots, so a CLI can render them from a single goroutine. Events polled by clients with WithProgress and the steps of workflows run by them are reported automatically. It is safe for concurrent use.
+type Progress struct {
+	mu      sync.Mutex
+	seq     uint64
+	ops     []OperationProgress
+	index   map[string]int
+	updates chan ProgressSnapshot
+	closed  bool
+}
+
+// NewProgress returns a Progress tracking no operations yet
+func NewProgress() *Progress {
+	return &Progress{
+		index:   make(map[string]int),
+		updates: make(chan ProgressSnapshot, 1),
+	}
+}
+
+// WithProgress reports the progress of the events the client waits for and of the workflows it runs to p
+func WithProgress(p *Progress) Option {
+	return func(c *Client) {
+		c.progress = p
+	}
+}
+
+// Updates returns the stream of snapshots. A reader which falls behind only misses intermediate snapshots, the latest one is always delivered. The channel is closed by Close.
+func (p *Progress) Updates() <-chan ProgressSnapshot {
+	return p.updates
+}
+
+// Report records the progress of an operation, adding it if it is new. Reports for a closed Progress are ignored.
+func (p *Progress) Report(op OperationProgress) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return
+	}
+
+	if i, ok := p.index[op.Key]; ok {
+		p.ops[i] = op
+	} else {
+		p.index[op.Key] = len(p.ops)
+		p.ops = append(p.ops, op)
+	}
+	p.seq++
+
+	// Replace a snapshot the reader hasn't taken yet, so it always gets the latest
+	select {
+	case <-p.updates:
+	default:
+	}
+	p.updates <- p.snapshot()
+}
+
+// Snapshot returns the current state of all operations
+func (p *Progress) Snapshot() ProgressSnapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.snapshot()
+}
+
+func (p *Progress) snapshot() ProgressSnapshot {
+	s := ProgressSnapshot{
+		Seq:        p.seq,
+		Operations: append([]OperationProgress(nil), p.ops...),
+		Total:      len(p.ops),
+	}
+
+	var sum float64
+	for _, op := range p.ops {
+		if op.Done {
+			s.Done++
+		}
+		if op.Err != nil {
+			s.Failed++
+		}
+		sum += op.Percentage
+	}
+
+	if s.Total > 0 {
+		s.Percentage = sum / float64(s.Total)
+	}
+
+	return s
+}
+
+// Close closes the stream of snapshots once all operations have been reported
+func (p *Progress) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.closed {
+		p.closed = true
+		close(p.updates)
+	}
+}
+
+// reportEvent reports the progress of an event the client waits for
+func (c *Client) reportEvent(eventID int, e *Event, err error) {
+	op := OperationProgress{Key: fmt.Sprintf("event %d", eventID), Err: err, Done: err != nil}
+	if e != nil {
+		op.Detail = e.ActionStatus
+		op.Percentage = e.Percentage
+		op.Done = op.Done || e.ActionStatus == EventStatusDone
+		if e.ActionStatus == EventStatusDone {
+			op.Percentage = 100
+		}
+	}
+
+	c.progress.Report(op)
+}
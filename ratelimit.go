@@ -0,0 +1,113 @@
+package godo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket which limits how fast requests are sent to the API. It is safe for concurrent use and can be shared between clients.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing rps requests per second on average and bursts of up to burst requests. It panics if rps isn't a positive finite number or burst is less than 1, like time.NewTicker does for a non-positive interval, since such a limiter would either never allow a request or never wait.
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	if !(rps > 0) || math.IsInf(rps, 1) {
+		panic(fmt.Sprintf("godo: rate limit must be a positive finite number of requests per second, got %v", rps))
+	}
+
+	if burst < 1 {
+		panic(fmt.Sprintf("godo: rate limit burst must be at least 1, got %d", burst))
+	}
+
+	return &RateLimiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request is allowed or ctx is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+
+		wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// Rate is the rate-limit state reported by the API in the last response
+type Rate struct {
+	// Limit is the number of requests allowed in the current window
+	Limit int
+	// Remaining is the number of requests left in the current window
+	Remaining int
+	// Reset is when the current window resets
+	Reset time.Time
+}
+
+// rateState holds the Rate from the last response which reported one
+type rateState struct {
+	mu   sync.RWMutex
+	rate Rate
+}
+
+func (s *rateState) update(h http.Header) {
+	if s == nil || h.Get("RateLimit-Limit") == "" {
+		return
+	}
+
+	var r Rate
+	r.Limit, _ = strconv.Atoi(h.Get("RateLimit-Limit"))
+	r.Remaining, _ = strconv.Atoi(h.Get("RateLimit-Remaining"))
+	if reset, err := strconv.ParseInt(h.Get("RateLimit-Reset"), 10, 64); err == nil {
+		r.Reset = time.Unix(reset, 0)
+	}
+
+	s.mu.Lock()
+	s.rate = r
+	s.mu.Unlock()
+}
+
+// RateLimit returns the rate-limit state from the last API response. The zero value is returned if the API hasn't reported any.
+func (c *Client) RateLimit() Rate {
+	if c.rate == nil {
+		return Rate{}
+	}
+
+	c.rate.mu.RLock()
+	defer c.rate.mu.RUnlock()
+
+	return c.rate.rate
+}
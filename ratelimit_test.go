@@ -0,0 +1,58 @@
+package godo
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestNewRateLimiterPanicsOnInvalidArguments(t *testing.T) {
+	tests := []struct {
+		name  string
+		rps   float64
+		burst int
+	}{
+		{"zero rate", 0, 1},
+		{"negative rate", -1, 1},
+		{"NaN rate", math.NaN(), 1},
+		{"infinite rate", math.Inf(1), 1},
+		{"zero burst", 1, 0},
+		{"negative burst", 1, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewRateLimiter(%v, %d) didn't panic", tt.rps, tt.burst)
+				}
+			}()
+
+			NewRateLimiter(tt.rps, tt.burst)
+		})
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	l := NewRateLimiter(50, 2)
+	ctx := context.Background()
+
+	start := time.Now()
+	for range 4 {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The burst is allowed right away, the other two requests wait 20ms each
+	if d := time.Since(start); d < 30*time.Millisecond || d > time.Second {
+		t.Errorf("4 requests took %v, want about 40ms", d)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := NewRateLimiter(0.001, 1).Wait(ctx); err != nil {
+		t.Fatalf("first request within the burst failed: %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+package godo
+
+import "fmt"
+
+// ReadOnlyError is returned by a read-only client for a call which would change resources. It matches ErrReadOnly with errors.Is.
+type ReadOnlyError struct {
+	Method   string
+	Endpoint string
+}
+
+func (e *ReadOnlyError) Error() string {
+	return fmt.Sprintf("refused %s %s: client is read-only", e.Method, e.Endpoint)
+}
+
+// Is reports whether target is ErrReadOnly
+func (e *ReadOnlyError) Is(target error) bool {
+	return target == ErrReadOnly
+}
+
+// WithReadOnly makes the client refuse every call which would change resources, i.e. those listed as mutating by Capabilities, with a ReadOnlyError. Nothing is sent for a refused call. This takes precedence over WithDryRun.
+func WithReadOnly() Option {
+	return func(c *Client) {
+		c.readOnly = true
+	}
+}
+
+// ReadOnly reports whether the client refuses mutating calls
+func (c *Client) ReadOnly() bool {
+	return c.readOnly
+}
+
+// checkReadOnly returns a ReadOnlyError if the client is read-only and the call is mutating
+func (c *Client) checkReadOnly(method, endpoint string) error {
+	if c.readOnly && isMutating(method, endpoint) {
+		return &ReadOnlyError{Method: method, Endpoint: endpoint}
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package godo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RegionCriteria describes the requirements for choosing a region automatically
+type RegionCriteria struct {
+	// SizeSlug is a size which must be available in the region
+	SizeSlug string
+
+	// Features must all be offered in the region
+	Features []Feature
+
+	// Latency is the latency to each region by slug, e.g. from MeasureRegionLatency. Regions with lower latency are preferred and regions without a measurement are ranked last.
+	Latency map[string]time.Duration
+
+	// Exclude lists slugs of regions which must never be chosen
+	Exclude []string
+}
+
+// hasSize reports whether the size is available in the region. Regions which don't report their sizes are assumed to offer all of them.
+func (r Region) hasSize(slug string) bool {
+	if slug == "" || len(r.Sizes) == 0 {
+		return true
+	}
+
+	for _, s := range r.Sizes {
+		if s == slug {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasFeature reports whether the feature is offered in the region. Regions which don't report their features are assumed to offer all of them.
+func (r Region) hasFeature(f Feature) bool {
+	if len(r.Features) == 0 {
+		return true
+	}
+
+	name := strings.Replace(string(f), " ", "_", -1)
+	for _, s := range r.Features {
+		if s == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ChooseRegion picks a region meeting the criteria, for workloads which don't care where they run. Droplet prices are the same in every region, so the candidates are ranked by latency and otherwise kept in the order returned by the API.
+func (c *Client) ChooseRegion(criteria RegionCriteria) (*Region, error) {
+	regions, err := c.GetAllRegions()
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := make(map[string]bool, len(criteria.Exclude))
+	for _, slug := range criteria.Exclude {
+		excluded[slug] = true
+	}
+
+	var candidates []Region
+	for _, r := range regions {
+		if excluded[r.Slug] || !r.hasSize(criteria.SizeSlug) {
+			continue
+		}
+
+		ok := true
+		for _, f := range criteria.Features {
+			if !r.hasFeature(f) || c.features.checkRegion(f, r) != nil {
+				ok = false
+				break
+			}
+		}
+
+		if ok {
+			candidates = append(candidates, r)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no region matches the criteria")
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		li, iok := criteria.Latency[candidates[i].Slug]
+		lj, jok := criteria.Latency[candidates[j].Slug]
+		if iok != jok {
+			return iok
+		}
+
+		return li < lj
+	})
+
+	return &candidates[0], nil
+}
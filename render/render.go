@@ -0,0 +1,137 @@
+// Package render formats droplets for humans, as the aligned tables CLIs built on godo print:
+//
+//	ID    NAME    STATUS   REGION   PUBLIC IP     AGE
+//	123   web-1   active   nyc2     192.0.2.10    3d
+//
+// The wide format adds the size, the private address, the image and whether the droplet is locked.
+package render
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pengux/godo"
+)
+
+// Options configures the output of Droplets
+type Options struct {
+	// Wide adds more columns
+	Wide bool
+
+	// Regions and Sizes map IDs to the slugs shown instead of the IDs, see Lookup. IDs which are missing are shown as is.
+	Regions map[int]string
+	Sizes   map[int]string
+
+	// Now is the time ages are relative to, the current time if zero
+	Now time.Time
+}
+
+// Lookup returns options which show the slugs of the regions and sizes of c instead of their IDs
+func Lookup(c *godo.Client) (Options, error) {
+	regions, err := c.GetAllRegions()
+	if err != nil {
+		return Options{}, err
+	}
+
+	sizes, err := c.GetAllSizes()
+	if err != nil {
+		return Options{}, err
+	}
+
+	opts := Options{
+		Regions: make(map[int]string, len(regions)),
+		Sizes:   make(map[int]string, len(sizes)),
+	}
+	for _, r := range regions {
+		opts.Regions[r.ID] = r.Slug
+	}
+	for _, s := range sizes {
+		opts.Sizes[s.ID] = s.Slug
+	}
+
+	return opts, nil
+}
+
+// Droplets writes droplets to w as a table with a header, one droplet per row in the given order
+func Droplets(w io.Writer, droplets []godo.Droplet, opts Options) error {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	header := []string{"ID", "NAME", "STATUS", "REGION", "PUBLIC IP", "AGE"}
+	if opts.Wide {
+		header = append(header, "SIZE", "PRIVATE IP", "IMAGE", "LOCKED")
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	for _, d := range droplets {
+		row := []string{
+			strconv.Itoa(d.ID),
+			d.Name,
+			orDash(string(d.Status)),
+			lookup(opts.Regions, d.RegionID),
+			orDash(d.IPAdress),
+			age(d.CreatedAt, now),
+		}
+		if opts.Wide {
+			row = append(row,
+				lookup(opts.Sizes, d.SizeID),
+				orDash(d.PrivateIPAddress),
+				strconv.Itoa(d.ImageID),
+				strconv.FormatBool(d.Locked),
+			)
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+// Age formats a duration in its largest whole unit, e.g. "3d", "5h", "12m" or "40s"
+func Age(d time.Duration) string {
+	switch {
+	case d < 0:
+		return "0s"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d/time.Second))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	}
+
+	return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+}
+
+// age returns the age of something created at t, or "-" if t is unknown
+func age(t, now time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	return Age(now.Sub(t))
+}
+
+// lookup returns the slug of ID, or the ID itself if it's missing from slugs
+func lookup(slugs map[int]string, ID int) string {
+	if s, ok := slugs[ID]; ok && s != "" {
+		return s
+	}
+
+	return strconv.Itoa(ID)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
@@ -0,0 +1,60 @@
+package godo
+
+import (
+	"context"
+	"net"
+)
+
+// Resolver resolves names to IP addresses
+type Resolver interface {
+	Lookup(name string) []net.IP
+}
+
+// Lookup returns the private IP addresses of the droplets named name, for droplet-to-droplet communication without public DNS
+func (w *DropletWatcher) Lookup(name string) []net.IP {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	var ips []net.IP
+	for _, d := range w.droplets {
+		if d.Name != name {
+			continue
+		}
+
+		if ip := net.ParseIP(d.PrivateIPAddress); ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+
+	return ips
+}
+
+// DialContext returns a dial function for http.Transport and similar, which connects to the addresses r returns for a host and falls back to dialer for hosts r doesn't know
+func DialContext(r Resolver, dialer *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	if dialer == nil {
+		dialer = &net.Dialer{}
+	}
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		ips := r.Lookup(host)
+		if len(ips) == 0 {
+			return dialer.DialContext(ctx, network, addr)
+		}
+
+		var lastErr error
+		for _, ip := range ips {
+			conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+			lastErr = err
+		}
+
+		return nil, lastErr
+	}
+}
@@ -0,0 +1,139 @@
+package godo
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// ErrRestartBudgetExceeded is matched by errors returned when a restart is refused because the droplet was restarted too often
+var ErrRestartBudgetExceeded = errors.New("restart budget exceeded")
+
+// RestartPolicy decides what happens when a droplet exceeds its restart budget
+type RestartPolicy int
+
+const (
+	// RestartWarn lets the restart through but reports it
+	RestartWarn RestartPolicy = iota
+	// RestartRefuse refuses the restart with ErrRestartBudgetExceeded
+	RestartRefuse
+)
+
+// RestartBudget tracks the reboots and power cycles issued per droplet over a sliding window. A droplet being bounced excessively usually indicates a failure loop in automation.
+type RestartBudget struct {
+	// Max is the number of restarts allowed per droplet within Window
+	Max    int
+	Window time.Duration
+	Policy RestartPolicy
+
+	// OnExceeded is called when a droplet exceeds the budget. If it is nil, a warning is logged to the client's Logger instead.
+	OnExceeded func(dropletID, restarts int)
+
+	mu       sync.Mutex
+	restarts map[int][]time.Time
+}
+
+// NewRestartBudget returns a budget allowing max restarts per droplet within window
+func NewRestartBudget(max int, window time.Duration, policy RestartPolicy) *RestartBudget {
+	return &RestartBudget{
+		Max:      max,
+		Window:   window,
+		Policy:   policy,
+		restarts: make(map[int][]time.Time),
+	}
+}
+
+// WithRestartBudget tracks reboots and power cycles issued by the client with b
+func WithRestartBudget(b *RestartBudget) Option {
+	return func(c *Client) {
+		c.RestartBudget = b
+	}
+}
+
+// Restarts returns how many restarts of the droplet were recorded within the window
+func (b *RestartBudget) Restarts(dropletID int) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.prune(dropletID, time.Now()))
+}
+
+func (b *RestartBudget) prune(dropletID int, now time.Time) []time.Time {
+	if b.restarts == nil {
+		b.restarts = make(map[int][]time.Time)
+	}
+
+	var kept []time.Time
+	for _, t := range b.restarts[dropletID] {
+		if now.Sub(t) < b.Window {
+			kept = append(kept, t)
+		}
+	}
+	b.restarts[dropletID] = kept
+
+	return kept
+}
+
+// check returns how many restarts the window would hold with one more restart of the droplet, and whether that exceeds the budget
+func (b *RestartBudget) check(dropletID int) (restarts int, exceeded bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	restarts = len(b.prune(dropletID, time.Now())) + 1
+
+	return restarts, restarts > b.Max
+}
+
+// record registers a restart of the droplet
+func (b *RestartBudget) record(dropletID int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.restarts[dropletID] = append(b.prune(dropletID, now), now)
+}
+
+// checkRestartBudget applies the budget's policy if another restart of the droplet would exceed it. The restart is only recorded by restartDroplet once the API has accepted it, so failed or refused restarts don't use up the budget.
+func (c *Client) checkRestartBudget(dropletID int) error {
+	b := c.RestartBudget
+	if b == nil {
+		return nil
+	}
+
+	restarts, exceeded := b.check(dropletID)
+	if !exceeded {
+		return nil
+	}
+
+	if b.OnExceeded != nil {
+		b.OnExceeded(dropletID, restarts)
+	} else if c.Logger != nil {
+		c.Logger.Warn("godo: droplet restarted excessively", slog.Int("droplet_id", dropletID), slog.Int("restarts", restarts), slog.Duration("window", b.Window))
+	}
+
+	if b.Policy == RestartRefuse {
+		return fmt.Errorf("could not restart droplet with ID %d: %w", dropletID, ErrRestartBudgetExceeded)
+	}
+
+	return nil
+}
+
+// restartDroplet restarts a droplet with an action within the client's restart budget
+func (c *Client) restartDroplet(ID int, action, what string) (int, error) {
+	if err := c.checkRestartBudget(ID); err != nil {
+		return 0, err
+	}
+
+	eventID, err := call[int](c, fmt.Sprintf("/droplets/%d/%s", ID, action), nil, "event_id", "%s droplet with ID %d", what, ID)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.RestartBudget != nil {
+		c.RestartBudget.record(ID)
+	}
+
+	return eventID, nil
+}
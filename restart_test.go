@@ -0,0 +1,53 @@
+package godo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRestartBudgetCountsAcceptedRestartsOnly(t *testing.T) {
+	var fail atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail.Load() {
+			fmt.Fprint(w, `{"status":"ERROR","message":"Droplet is currently locked"}`)
+			return
+		}
+		fmt.Fprint(w, `{"status":"OK","event_id":1}`)
+	}))
+	defer srv.Close()
+
+	exceeded := 0
+	b := NewRestartBudget(1, time.Hour, RestartRefuse)
+	b.OnExceeded = func(dropletID, restarts int) { exceeded++ }
+	c := NewClient(WithCredentials("id", "key"), WithBaseURL(srv.URL), WithRestartBudget(b))
+
+	fail.Store(true)
+	for range 3 {
+		if _, err := c.RebootDroplet(1); !errors.Is(err, ErrDropletLocked) {
+			t.Fatalf("got error %v, want %v", err, ErrDropletLocked)
+		}
+	}
+	if got := b.Restarts(1); got != 0 {
+		t.Errorf("failed reboots recorded %d restarts, want 0", got)
+	}
+
+	fail.Store(false)
+	if _, err := c.PowerCycleDroplet(1); err != nil {
+		t.Fatalf("restart within the budget failed: %v", err)
+	}
+	if exceeded != 0 {
+		t.Errorf("budget was reported as exceeded %d times, want 0", exceeded)
+	}
+
+	if _, err := c.RebootDroplet(1); !errors.Is(err, ErrRestartBudgetExceeded) {
+		t.Errorf("got error %v, want %v", err, ErrRestartBudgetExceeded)
+	}
+	if exceeded != 1 || b.Restarts(1) != 1 {
+		t.Errorf("got %d reports and %d restarts, want 1 each", exceeded, b.Restarts(1))
+	}
+}
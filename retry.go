@@ -0,0 +1,105 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// retryBaseDelay is the delay before the first retry, it is doubled for each following attempt
+	retryBaseDelay = 500 * time.Millisecond
+	// retryMaxDelay caps the delay between retries
+	retryMaxDelay = 30 * time.Second
+)
+
+// shouldRetry reports whether a request which got resp and err is worth sending again. A mutating request which may have been carried out, i.e. one which failed with a network error after it was sent or with a server error, isn't sent again, since e.g. a retried create could create a second droplet. It is only retried when the server refused it with 429 or it never reached the server.
+func shouldRetry(resp *http.Response, err error, mutating bool) bool {
+	if err != nil {
+		return !mutating || notSent(err)
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	return !mutating && resp.StatusCode >= http.StatusInternalServerError
+}
+
+// notSent reports whether a request failed before it reached the server, i.e. while resolving the host or connecting to it
+func notSent(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// backoff returns the delay before retrying after the given attempt
+func backoff(attempt int) time.Duration {
+	d := retryBaseDelay << uint(attempt)
+	if d <= 0 || d > retryMaxDelay {
+		return retryMaxDelay
+	}
+
+	return d
+}
+
+// retryDelay returns the delay before retrying after the given attempt. For a 429 or 503 response it is the delay the server asks for with Retry-After, if any.
+func retryDelay(resp *http.Response, attempt int) time.Duration {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return d
+		}
+	}
+
+	return backoff(attempt)
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number of seconds or an HTTP date
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+
+		return time.Duration(secs) * time.Second, true
+	}
+
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(t.Sub(now), 0), true
+}
+
+// outlivesContext reports whether ctx is done before a delay passes, in which case waiting for a retry is pointless
+func outlivesContext(ctx context.Context, d time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return ok && time.Until(deadline) < d
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
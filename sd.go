@@ -0,0 +1,110 @@
+package godo
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"path"
+	"strconv"
+)
+
+// SDTargetGroup is a target group in the format of Prometheus' file_sd and HTTP service discovery
+type SDTargetGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+// SDConfig configures how droplets are rendered as service discovery targets
+type SDConfig struct {
+	// Port is the port scraped on each droplet
+	Port int
+	// Private uses the private IP address of the droplets instead of the public one
+	Private bool
+	// Filter selects the droplets to render, all droplets are rendered if it is nil
+	Filter func(Droplet) bool
+}
+
+// NameFilter returns an SDConfig filter selecting droplets whose name matches the glob pattern, e.g. "web-*"
+func NameFilter(pattern string) func(Droplet) bool {
+	return func(d Droplet) bool {
+		ok, _ := path.Match(pattern, d.Name)
+		return ok
+	}
+}
+
+// SDTargets renders droplets as one target group each, labeled with their metadata. Droplets without an address are left out.
+func SDTargets(droplets []Droplet, cfg SDConfig) []SDTargetGroup {
+	groups := []SDTargetGroup{}
+	for _, d := range droplets {
+		if cfg.Filter != nil && !cfg.Filter(d) {
+			continue
+		}
+
+		ip := d.IPAdress
+		if cfg.Private {
+			ip = d.PrivateIPAddress
+		}
+		if ip == "" {
+			continue
+		}
+
+		groups = append(groups, SDTargetGroup{
+			Targets: []string{net.JoinHostPort(ip, strconv.Itoa(cfg.Port))},
+			Labels: map[string]string{
+				"__meta_digitalocean_droplet_id":   strconv.Itoa(d.ID),
+				"__meta_digitalocean_droplet_name": d.Name,
+				"__meta_digitalocean_status":       string(d.Status),
+				"__meta_digitalocean_region_id":    strconv.Itoa(d.RegionID),
+				"__meta_digitalocean_size_id":      strconv.Itoa(d.SizeID),
+				"__meta_digitalocean_image_id":     strconv.Itoa(d.ImageID),
+			},
+		})
+	}
+
+	return groups
+}
+
+// WriteFileSD atomically writes target groups to a Prometheus file_sd JSON file
+func WriteFileSD(filename string, groups []SDTargetGroup) error {
+	b, err := json.MarshalIndent(groups, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filename, b)
+}
+
+// RunFileSD rewrites the file_sd file whenever the watcher observes a change, until ctx is done or the watcher stops. The watcher must be run separately.
+func RunFileSD(ctx context.Context, w *DropletWatcher, filename string, cfg SDConfig) error {
+	// Only the latest state matters, so a single pending notification is enough
+	sub := w.Subscribe(1, DropOldest)
+	defer sub.Close()
+
+	if err := WriteFileSD(filename, SDTargets(w.Droplets(), cfg)); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case _, ok := <-sub.C:
+			if !ok {
+				return nil
+			}
+
+			if err := WriteFileSD(filename, SDTargets(w.Droplets(), cfg)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// SDHandler serves the droplets known to the watcher in the format of Prometheus' HTTP service discovery
+func SDHandler(w *DropletWatcher, cfg SDConfig) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(rw).Encode(SDTargets(w.Droplets(), cfg))
+	})
+}
@@ -0,0 +1,80 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// errFlightPanicked is returned to the callers sharing a request which panicked while it was sent
+var errFlightPanicked = errors.New("shared request panicked")
+
+// flightCall is a request in flight whose result is shared by all callers asking for the same thing
+type flightCall struct {
+	done chan struct{}
+	body []byte
+	err  error
+}
+
+// flightGroup collapses concurrent identical requests into a single one
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// do starts fn in the background, unless a call with the same key is already in flight, and waits for its result until ctx is done. fn must not depend on the context of any caller, as the callers sharing it may give up at any time without canceling it. A panic of fn is returned as an error to all callers, since there is no caller to pass it on to.
+func (g *flightGroup) do(ctx context.Context, key string, fn func() ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+	call, ok := g.calls[key]
+	if !ok {
+		call = &flightCall{done: make(chan struct{})}
+		g.calls[key] = call
+		go g.run(key, call, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.body, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// run calls fn for call and releases the callers waiting for it
+func (g *flightGroup) run(key string, call *flightCall, fn func() ([]byte, error)) {
+	defer func() {
+		if v := recover(); v != nil {
+			call.body, call.err = nil, fmt.Errorf("%w: %v", errFlightPanicked, v)
+		}
+
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+	}()
+
+	call.body, call.err = fn()
+}
+
+// flightTimeout bounds a shared request, which isn't canceled with the context of any caller: it is the client's request timeout, or the timeout of its HTTP client, or DefaultTimeout
+func (c *Client) flightTimeout() time.Duration {
+	if c.requestTimeout > 0 {
+		return c.requestTimeout
+	}
+
+	if t := c.httpClient().Timeout; t > 0 {
+		return t
+	}
+
+	return DefaultTimeout
+}
+
+// WithRequestDeduplication collapses concurrent identical GET requests, e.g. many goroutines asking for /regions at once, into a single request whose response is shared. The shared request is detached from the contexts of the callers and bounded by the request timeout, so a caller giving up only stops its own wait.
+func WithRequestDeduplication() Option {
+	return func(c *Client) {
+		c.flights = &flightGroup{calls: make(map[string]*flightCall)}
+	}
+}
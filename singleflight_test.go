@@ -0,0 +1,78 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFlightGroupPanic(t *testing.T) {
+	g := &flightGroup{calls: make(map[string]*flightCall)}
+
+	_, err := g.do(context.Background(), "/regions", func() ([]byte, error) {
+		panic("boom")
+	})
+	if !errors.Is(err, errFlightPanicked) {
+		t.Errorf("got error %v, want %v", err, errFlightPanicked)
+	}
+
+	if _, err := g.do(context.Background(), "/regions", func() ([]byte, error) { return []byte("ok"), nil }); err != nil {
+		t.Errorf("request after the panic failed: %v", err)
+	}
+}
+
+func TestDeduplicatedRequestSurvivesCanceledCaller(t *testing.T) {
+	var requests atomic.Int64
+	received := make(chan struct{})
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			close(received)
+		}
+		<-release
+		fmt.Fprint(w, `{"status":"OK","regions":[{"id":4,"name":"New York 2","slug":"nyc2"}]}`)
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	c := NewClient(WithCredentials("id", "key"), WithBaseURL(srv.URL), WithRetries(0), WithRequestDeduplication())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := c.WithContext(ctx).GetAllRegions()
+		first <- err
+	}()
+	<-received
+
+	second := make(chan error)
+	go func() {
+		regions, err := c.GetAllRegions()
+		if err == nil && len(regions) != 1 {
+			err = fmt.Errorf("got %d regions, want 1", len(regions))
+		}
+		second <- err
+	}()
+
+	// Give the second caller time to join the request in flight
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("canceled caller got error %v, want %v", err, context.Canceled)
+	}
+
+	release <- struct{}{}
+	if err := <-second; err != nil {
+		t.Errorf("second caller failed after the first was canceled: %v", err)
+	}
+
+	if got := requests.Load(); got != 1 {
+		t.Errorf("sent %d requests, want 1", got)
+	}
+}
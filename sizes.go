@@ -0,0 +1,74 @@
+package godo
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SizeRequirements describes the minimum resources a droplet needs. Zero values are ignored.
+type SizeRequirements struct {
+	Memory int
+	CPU    int
+	Disk   int
+}
+
+// sortSizes sorts sizes from smallest to largest by memory, CPU, disk and finally cost
+func sortSizes(sizes []Size) []Size {
+	sorted := append([]Size(nil), sizes...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sizeLess(sorted[i], sorted[j])
+	})
+
+	return sorted
+}
+
+func sizeLess(a, b Size) bool {
+	if a.Memory != b.Memory {
+		return a.Memory < b.Memory
+	}
+	if a.CPU != b.CPU {
+		return a.CPU < b.CPU
+	}
+	if a.Disk != b.Disk {
+		return a.Disk < b.Disk
+	}
+
+	return a.CostPerHour < b.CostPerHour
+}
+
+// SmallestSize returns the smallest of sizes which meets the requirements
+func SmallestSize(sizes []Size, req SizeRequirements) (*Size, error) {
+	for _, s := range sortSizes(sizes) {
+		if s.Memory >= req.Memory && s.CPU >= req.CPU && s.Disk >= req.Disk {
+			return &s, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no size with at least %dMB memory, %d CPUs and %dGB disk", req.Memory, req.CPU, req.Disk)
+}
+
+// NextSizeUp returns the smallest available size which is larger than current
+func (c *Client) NextSizeUp(current Size) (*Size, error) {
+	sizes, err := c.GetAllSizes()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range sortSizes(sizes) {
+		if s.ID != current.ID && sizeLess(current, s) {
+			return &s, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no size larger than %s", current.Slug)
+}
+
+// PlanResize returns the smallest available size which meets the requirements. Its ID or slug can be passed to ResizeDroplet.
+func (c *Client) PlanResize(req SizeRequirements) (*Size, error) {
+	sizes, err := c.GetAllSizes()
+	if err != nil {
+		return nil, err
+	}
+
+	return SmallestSize(sizes, req)
+}
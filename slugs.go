@@ -0,0 +1,150 @@
+package godo
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultSlugCacheTTL is how long slug resolutions are cached unless configured with WithSlugCacheTTL
+const DefaultSlugCacheTTL = 10 * time.Minute
+
+type slugEntry struct {
+	id      int
+	expires time.Time
+}
+
+// slugCache memoizes slug to ID resolutions of sizes, regions and images
+type slugCache struct {
+	ttl time.Duration
+
+	mu      sync.RWMutex
+	entries map[string]slugEntry
+}
+
+func newSlugCache(ttl time.Duration) *slugCache {
+	return &slugCache{
+		ttl:     ttl,
+		entries: make(map[string]slugEntry),
+	}
+}
+
+func (s *slugCache) get(kind, slug string) (int, bool) {
+	if s == nil {
+		return 0, false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, ok := s.entries[kind+":"+slug]
+	if !ok || time.Now().After(e.expires) {
+		return 0, false
+	}
+
+	return e.id, true
+}
+
+func (s *slugCache) set(kind string, ids map[string]int) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expires := time.Now().Add(s.ttl)
+	for slug, id := range ids {
+		s.entries[kind+":"+slug] = slugEntry{id, expires}
+	}
+}
+
+// WithSlugCacheTTL sets how long slug resolutions are cached, 0 disables caching
+func WithSlugCacheTTL(d time.Duration) Option {
+	return func(c *Client) {
+		if d <= 0 {
+			c.slugs = nil
+			return
+		}
+		c.slugs = newSlugCache(d)
+	}
+}
+
+// resolveSlug returns the ID for slug from the cache, or calls fetch for all IDs by slug of the kind and caches them
+func (c *Client) resolveSlug(kind, slug string, fetch func() (map[string]int, error)) (int, error) {
+	if id, ok := c.slugs.get(kind, slug); ok {
+		return id, nil
+	}
+
+	ids, err := fetch()
+	if err != nil {
+		return 0, err
+	}
+	c.slugs.set(kind, ids)
+
+	id, ok := ids[slug]
+	if !ok {
+		return 0, fmt.Errorf("could not find %s with slug %q", kind, slug)
+	}
+
+	return id, nil
+}
+
+// ResolveSizeSlug returns the ID of the size with the given slug. Resolutions are cached on the client.
+func (c *Client) ResolveSizeSlug(slug string) (int, error) {
+	return c.resolveSlug("size", slug, c.sizeIDs)
+}
+
+// ResolveRegionSlug returns the ID of the region with the given slug. Resolutions are cached on the client.
+func (c *Client) ResolveRegionSlug(slug string) (int, error) {
+	return c.resolveSlug("region", slug, c.regionIDs)
+}
+
+// ResolveImageSlug returns the ID of the image with the given slug. Resolutions are cached on the client.
+func (c *Client) ResolveImageSlug(slug string) (int, error) {
+	return c.resolveSlug("image", slug, c.imageIDs)
+}
+
+func (c *Client) sizeIDs() (map[string]int, error) {
+	sizes, err := c.GetAllSizes()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]int, len(sizes))
+	for _, s := range sizes {
+		ids[s.Slug] = s.ID
+	}
+
+	return ids, nil
+}
+
+func (c *Client) regionIDs() (map[string]int, error) {
+	regions, err := c.GetAllRegions()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]int, len(regions))
+	for _, r := range regions {
+		ids[r.Slug] = r.ID
+	}
+
+	return ids, nil
+}
+
+func (c *Client) imageIDs() (map[string]int, error) {
+	images, err := c.GetAllImages()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]int, len(images))
+	for _, i := range images {
+		if i.Slug != "" {
+			ids[i.Slug] = i.ID
+		}
+	}
+
+	return ids, nil
+}
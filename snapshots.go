@@ -0,0 +1,102 @@
+package godo
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// snapshotLayout is the format of the time encoded in snapshot names. It sorts lexically in chronological order.
+const snapshotLayout = "20060102t150405z"
+
+var snapshotPattern = regexp.MustCompile(`^snap-(\d+)-(.*)-(\d{8}t\d{6}z)$`)
+
+// SnapshotName is the metadata encoded in the name of a snapshot by NameSnapshot
+type SnapshotName struct {
+	DropletID   int
+	DropletName string
+	TakenAt     time.Time
+}
+
+// NameSnapshot returns the canonical name of a snapshot of a droplet taken at t, e.g. "snap-123-web-1-20141016t150405z". The name encodes the droplet and the time in UTC so snapshots can be attributed and ordered by name alone.
+func NameSnapshot(d Droplet, t time.Time) string {
+	return fmt.Sprintf("snap-%d-%s-%s", d.ID, d.Name, strings.ToLower(t.UTC().Format(snapshotLayout)))
+}
+
+// ParseSnapshotName returns the metadata encoded in a snapshot name by NameSnapshot
+func ParseSnapshotName(name string) (SnapshotName, bool) {
+	m := snapshotPattern.FindStringSubmatch(name)
+	if m == nil {
+		return SnapshotName{}, false
+	}
+
+	ID, err := strconv.Atoi(m[1])
+	if err != nil {
+		return SnapshotName{}, false
+	}
+
+	t, err := time.Parse(snapshotLayout, m[3])
+	if err != nil {
+		return SnapshotName{}, false
+	}
+
+	return SnapshotName{DropletID: ID, DropletName: m[2], TakenAt: t}, true
+}
+
+// Snapshot is an image taken of a droplet
+type Snapshot struct {
+	Image
+	// CreatedAt is when the snapshot was taken as reported by the API, or else as encoded in its name by NameSnapshot. It is zero if neither tells.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// dropletImages returns the images of a droplet listed in the field key of the droplet, e.g. its snapshots
+func (c *Client) dropletImages(ID int, key string) ([]Snapshot, error) {
+	droplet, err := call[map[string]json.RawMessage](c, fmt.Sprintf("/droplets/%d", ID), nil, "droplet", "get %s of droplet with ID %d", key, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots, err := decodeField[[]Snapshot](droplet, key, "get %s of droplet with ID %d", key, ID)
+	if err != nil {
+		return nil, err
+	}
+	for i, s := range snapshots {
+		if !s.CreatedAt.IsZero() {
+			continue
+		}
+
+		if sn, ok := ParseSnapshotName(s.Name); ok {
+			snapshots[i].CreatedAt = sn.TakenAt
+		}
+	}
+
+	return snapshots, nil
+}
+
+// GetDropletSnapshots returns the snapshots of a droplet
+func (c *Client) GetDropletSnapshots(ID int) ([]Snapshot, error) {
+	return c.dropletImages(ID, "snapshots")
+}
+
+// GetDropletBackups returns the automatic backups of a droplet, see LatestSnapshot to pick the most recent one
+func (c *Client) GetDropletBackups(ID int) ([]Snapshot, error) {
+	return c.dropletImages(ID, "backups")
+}
+
+// LatestSnapshot returns the most recent of snapshots or backups, or nil if there are none. Images whose creation time is unknown count as older than those whose time is known and are ordered among themselves by ID, which the API assigns in increasing order.
+func LatestSnapshot(snapshots []Snapshot) *Snapshot {
+	var latest *Snapshot
+	for i, s := range snapshots {
+		if latest == nil ||
+			s.CreatedAt.After(latest.CreatedAt) ||
+			s.CreatedAt.Equal(latest.CreatedAt) && s.ID > latest.ID {
+			latest = &snapshots[i]
+		}
+	}
+
+	return latest
+}
@@ -0,0 +1,75 @@
+package godo
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// sniffSize is how much of a body is inspected to tell whether it is JSON
+const sniffSize = 512
+
+// excerptSize is the length of the body excerpt in an UnexpectedResponseError
+const excerptSize = 200
+
+// UnexpectedResponseError is returned for a response which isn't JSON, e.g. an HTML error page of a proxy or an empty body. For an error status it wraps the APIError of the status, so it still matches e.g. ErrNotFound with errors.Is.
+type UnexpectedResponseError struct {
+	Endpoint    string
+	StatusCode  int
+	ContentType string
+	// Excerpt is the start of the body
+	Excerpt string
+	Err     error
+}
+
+func (e *UnexpectedResponseError) Error() string {
+	if e.Excerpt == "" {
+		return fmt.Sprintf("unexpected empty response with status %d from %s", e.StatusCode, e.Endpoint)
+	}
+
+	return fmt.Sprintf("unexpected %s response with status %d from %s: %q", e.ContentType, e.StatusCode, e.Endpoint, e.Excerpt)
+}
+
+// Unwrap returns the APIError of an error status
+func (e *UnexpectedResponseError) Unwrap() error {
+	return e.Err
+}
+
+// sniffJSON returns an UnexpectedResponseError if the body of a response, buffered in br, isn't JSON according to its content type or its first bytes
+func (c *Client) sniffJSON(endpoint string, resp *http.Response, br *bufio.Reader) error {
+	head, _ := br.Peek(sniffSize)
+	head = bytes.TrimSpace(head)
+
+	ct := resp.Header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(ct)
+
+	isJSON := len(head) > 0 && (head[0] == '{' || head[0] == '[')
+	if isJSON && (mediaType == "" || strings.Contains(mediaType, "json") || mediaType == "text/plain") {
+		return nil
+	}
+
+	if ct == "" {
+		ct = http.DetectContentType(head)
+	}
+
+	excerpt := strings.Join(strings.Fields(string(head)), " ")
+	if len(excerpt) > excerptSize {
+		excerpt = excerpt[:excerptSize] + "..."
+	}
+
+	e := &UnexpectedResponseError{
+		Endpoint:    endpoint,
+		StatusCode:  resp.StatusCode,
+		ContentType: ct,
+		Excerpt:     excerpt,
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		e.Err = c.apiError(endpoint, resp.StatusCode, resp.Status)
+	}
+
+	return e
+}
@@ -0,0 +1,194 @@
+package godo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+)
+
+// Codec reads and writes resource specs such as NewDroplet and DomainSpec in a file format. Specs are mapped to the format with their JSON field names.
+type Codec interface {
+	Encode(w io.Writer, v interface{}) error
+	Decode(r io.Reader, v interface{}) error
+}
+
+var (
+	// JSON is the Codec of indented JSON
+	JSON Codec = jsonCodec{}
+	// YAML is the Codec of the subset of YAML made of block mappings and sequences, flow sequences of scalars and comments
+	YAML Codec = yamlCodec{}
+	// HCL is the Codec of a minimal HCL-like format of "key = value" attributes and "key { ... }" blocks
+	HCL Codec = hclCodec{}
+)
+
+// CodecForFile returns the Codec of a file by its extension: .json, .yaml, .yml or .hcl
+func CodecForFile(path string) (Codec, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return JSON, nil
+	case ".yaml", ".yml":
+		return YAML, nil
+	case ".hcl":
+		return HCL, nil
+	}
+
+	return nil, fmt.Errorf("unknown spec format of %s", path)
+}
+
+// DomainSpec is the desired state of a domain and its records, see EnsureDomain
+type DomainSpec struct {
+	Name    string         `json:"name"`
+	IP      string         `json:"ip,omitempty"`
+	Records []DomainRecord `json:"records,omitempty"`
+}
+
+// ApplyDomainSpec converges a domain to spec with EnsureDomain
+func (c *Client) ApplyDomainSpec(ctx context.Context, spec DomainSpec) (*SyncReport, error) {
+	var ip net.IP
+	if spec.IP != "" {
+		if ip = net.ParseIP(spec.IP); ip == nil {
+			return nil, fmt.Errorf("invalid IP address %q for domain %s", spec.IP, spec.Name)
+		}
+	}
+
+	return c.EnsureDomain(ctx, spec.Name, ip, spec.Records)
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Encode(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func (jsonCodec) Decode(r io.Reader, v interface{}) error {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// The YAML and HCL codecs convert specs to and from a tree via JSON, so both follow the JSON field names. A tree is an object, a []interface{}, a string, a json.Number, a bool or nil.
+
+// field is a member of an object
+type field struct {
+	key   string
+	value interface{}
+}
+
+// object is a JSON object which keeps the order of its fields
+type object []field
+
+// toTree converts v to a tree through its JSON encoding
+func toTree(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	return readTree(dec)
+}
+
+func readTree(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		obj := object{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := readTree(dec)
+			if err != nil {
+				return nil, err
+			}
+
+			obj = append(obj, field{key.(string), value})
+		}
+
+		_, err := dec.Token()
+		return obj, err
+	case json.Delim('['):
+		list := []interface{}{}
+		for dec.More() {
+			value, err := readTree(dec)
+			if err != nil {
+				return nil, err
+			}
+
+			list = append(list, value)
+		}
+
+		_, err := dec.Token()
+		return list, err
+	}
+
+	return tok, nil
+}
+
+// fromTree decodes a tree into v through its JSON encoding
+func fromTree(tree interface{}, v interface{}) error {
+	var buf bytes.Buffer
+	if err := writeJSONTree(&buf, tree); err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(&buf)
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+func writeJSONTree(buf *bytes.Buffer, tree interface{}) error {
+	switch t := tree.(type) {
+	case object:
+		buf.WriteByte('{')
+		for i, f := range t {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			key, _ := json.Marshal(f.key)
+			buf.Write(key)
+			buf.WriteByte(':')
+
+			if err := writeJSONTree(buf, f.value); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, v := range t {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			if err := writeJSONTree(buf, v); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		data, err := json.Marshal(t)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+	}
+
+	return nil
+}
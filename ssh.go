@@ -0,0 +1,98 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// SSHAddress waits until a droplet is active and its SSH server answers, see WaitForSSH, and returns the address of the server, e.g. "192.0.2.10:22". The wait is bounded by the client's operation timeout. In dry-run mode a planned droplet has no address, so an empty one is returned.
+func (c *Client) SSHAddress(ctx context.Context, ID int) (string, error) {
+	d, err := c.WaitForDropletStatus(ctx, ID, DropletStatusActive)
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.WaitForSSH(ctx, d); err != nil {
+		return "", err
+	}
+
+	if d.IPAdress == "" {
+		return "", nil
+	}
+
+	return net.JoinHostPort(d.IPAdress, "22"), nil
+}
+
+// DefaultSSHDialAttempts is how often DialSSH dials before it gives up
+const DefaultSSHDialAttempts = 10
+
+// PermanentError marks an error of a dial function passed to DialSSH which retrying won't fix, e.g. a rejected host key, so DialSSH returns it right away
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// DialSSH waits for the SSH server of a droplet with SSHAddress and connects to it with dial, so provisioning can start right after the droplet is created. A failed dial is retried at the client's poll interval, as the server may answer before it accepts the keys of the droplet, up to DefaultSSHDialAttempts attempts in total. An error wrapped in a PermanentError isn't retried. The wait is bounded by the client's operation timeout. godo doesn't depend on an SSH implementation, so dial establishes the connection, e.g. with golang.org/x/crypto/ssh, verifying the host key against a known_hosts file:
+//
+//	hostKeys, err := knownhosts.New(knownHostsFile)
+//	if err != nil {
+//		return err
+//	}
+//
+//	client, err := godo.DialSSH(ctx, c, ID, func(ctx context.Context, addr string) (*ssh.Client, error) {
+//		client, err := ssh.Dial("tcp", addr, &ssh.ClientConfig{
+//			User:            "root",
+//			Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+//			HostKeyCallback: hostKeys,
+//		})
+//
+//		var keyErr *knownhosts.KeyError
+//		if errors.As(err, &keyErr) {
+//			// An unknown or changed host key won't be fixed by dialing again
+//			return nil, &godo.PermanentError{Err: err}
+//		}
+//
+//		return client, err
+//	})
+//
+// In dry-run mode a planned droplet isn't dialed and the zero value is returned.
+func DialSSH[T any](ctx context.Context, c *Client, ID int, dial func(ctx context.Context, addr string) (T, error)) (T, error) {
+	var zero T
+
+	addr, err := c.SSHAddress(ctx, ID)
+	if err != nil {
+		return zero, err
+	}
+
+	if addr == "" {
+		return zero, nil
+	}
+
+	ctx, cancel := c.operationContext(ctx)
+	defer cancel()
+
+	for attempt := 1; ; attempt++ {
+		conn, err := dial(ctx, addr)
+		if err == nil {
+			return conn, nil
+		}
+
+		var permanent *PermanentError
+		if errors.As(err, &permanent) || attempt >= DefaultSSHDialAttempts {
+			return zero, fmt.Errorf("could not connect to droplet with ID %d over SSH: %w", ID, err)
+		}
+
+		if sleepErr := c.sleep(ctx); sleepErr != nil {
+			return zero, fmt.Errorf("could not connect to droplet with ID %d over SSH: %w", ID, errors.Join(err, sleepErr))
+		}
+	}
+}
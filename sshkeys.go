@@ -0,0 +1,149 @@
+package godo
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SSHKey maps to the ssh_key(s) field in the response. PublicKey is only set for a single key.
+type SSHKey struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	PublicKey string `json:"ssh_pub_key"`
+}
+
+// GetAllSSHKeys returns all SSH keys of the account
+func (c *Client) GetAllSSHKeys() ([]SSHKey, error) {
+	return list[SSHKey](c, "/ssh_keys", nil, "ssh_keys", "get SSH keys")
+}
+
+// GetSSHKeyByID returns an SSH key including its public key
+func (c *Client) GetSSHKeyByID(ID int) (*SSHKey, error) {
+	v, err := call[SSHKey](c, fmt.Sprintf("/ssh_keys/%d", ID), nil, "ssh_key", "get SSH key with ID %d", ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// CreateSSHKey adds a public key in the authorized_keys format to the account
+func (c *Client) CreateSSHKey(name, publicKey string) (*SSHKey, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name must be set")
+	}
+
+	if publicKey == "" {
+		return nil, fmt.Errorf("public key must be set")
+	}
+
+	v, err := call[SSHKey](c, "/ssh_keys/new", url.Values{"name": {name}, "ssh_pub_key": {publicKey}}, "ssh_key", "create SSH key")
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// UpdateSSHKey changes the name and public key of an SSH key
+func (c *Client) UpdateSSHKey(ID int, name, publicKey string) (*SSHKey, error) {
+	if publicKey == "" {
+		return nil, fmt.Errorf("public key must be set")
+	}
+
+	params := url.Values{"ssh_pub_key": {publicKey}}
+	if name != "" {
+		params.Set("name", name)
+	}
+
+	v, err := call[SSHKey](c, fmt.Sprintf("/ssh_keys/%d/edit", ID), params, "ssh_key", "update SSH key with ID %d", ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// DeleteSSHKey removes an SSH key from the account
+func (c *Client) DeleteSSHKey(ID int) error {
+	_, err := call[struct{}](c, fmt.Sprintf("/ssh_keys/%d/destroy", ID), nil, "", "delete SSH key with ID %d", ID)
+	return err
+}
+
+// SSHKeyFingerprint returns the MD5 fingerprint of a public key in the authorized_keys format, e.g. "43:51:43:a1:b5:fc:8b:b7:0a:3a:a9:b1:0f:66:73:a8", which is how DigitalOcean identifies keys
+func SSHKeyFingerprint(publicKey string) (string, error) {
+	fields := strings.Fields(publicKey)
+	if len(fields) < 2 {
+		return "", fmt.Errorf("public key must be in the authorized_keys format")
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return "", fmt.Errorf("could not decode public key: %w", err)
+	}
+
+	sum := md5.Sum(blob)
+	hex := make([]string, len(sum))
+	for i, b := range sum {
+		hex[i] = fmt.Sprintf("%02x", b)
+	}
+
+	return strings.Join(hex, ":"), nil
+}
+
+// EnsureSSHKey makes sure publicKey is stored in the account under name and returns its ID for NewDroplet.SSHKeyIDs. A key with the same fingerprint is renamed if its name differs; a key with the same name but a different fingerprint gets the new public key; otherwise the key is uploaded.
+func (c *Client) EnsureSSHKey(ctx context.Context, name, publicKey string) (int, error) {
+	fingerprint, err := SSHKeyFingerprint(publicKey)
+	if err != nil {
+		return 0, err
+	}
+
+	cc := c.WithContext(ctx)
+
+	keys, err := cc.GetAllSSHKeys()
+	if err != nil {
+		return 0, err
+	}
+
+	var named *SSHKey
+	for _, k := range keys {
+		// Listed keys lack the public key
+		key, err := cc.GetSSHKeyByID(k.ID)
+		if err != nil {
+			return 0, err
+		}
+
+		if fp, err := SSHKeyFingerprint(key.PublicKey); err == nil && fp == fingerprint {
+			if key.Name != name {
+				if _, err := cc.UpdateSSHKey(key.ID, name, key.PublicKey); err != nil {
+					return 0, err
+				}
+			}
+
+			return key.ID, nil
+		}
+
+		if key.Name == name && named == nil {
+			named = key
+		}
+	}
+
+	if named != nil {
+		if _, err := cc.UpdateSSHKey(named.ID, name, publicKey); err != nil {
+			return 0, err
+		}
+
+		return named.ID, nil
+	}
+
+	key, err := cc.CreateSSHKey(name, publicKey)
+	if err != nil {
+		return 0, err
+	}
+
+	return key.ID, nil
+}
@@ -0,0 +1,191 @@
+package godo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Store persists the state of long-running helpers, e.g. workflow runs, so a daemon can pick up where it left off after a restart. Values are grouped in namespaces, one per helper. Implementations must be safe for concurrent use.
+type Store interface {
+	// Get returns the value of a key. The error matches ErrNotFound if there is none.
+	Get(ctx context.Context, namespace, key string) ([]byte, error)
+	Put(ctx context.Context, namespace, key string, value []byte) error
+	// List returns the keys of a namespace in order
+	List(ctx context.Context, namespace string) ([]string, error)
+	// Delete removes a key, it is not an error if there is none
+	Delete(ctx context.Context, namespace, key string) error
+}
+
+// keyNotFound returns the error of a Store for a missing key
+func keyNotFound(namespace, key string) error {
+	return fmt.Errorf("key %q in namespace %q: %w", key, namespace, ErrNotFound)
+}
+
+// MemoryStore is a Store which keeps values in memory, e.g. for tests or short-lived processes
+type MemoryStore struct {
+	mu         sync.Mutex
+	namespaces map[string]map[string][]byte
+}
+
+// NewMemoryStore returns an empty MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{namespaces: make(map[string]map[string][]byte)}
+}
+
+// Get returns the value of a key
+func (m *MemoryStore) Get(ctx context.Context, namespace, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	v, ok := m.namespaces[namespace][key]
+	if !ok {
+		return nil, keyNotFound(namespace, key)
+	}
+
+	return append([]byte(nil), v...), nil
+}
+
+// Put sets the value of a key
+func (m *MemoryStore) Put(ctx context.Context, namespace, key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ns, ok := m.namespaces[namespace]
+	if !ok {
+		ns = make(map[string][]byte)
+		m.namespaces[namespace] = ns
+	}
+	ns[key] = append([]byte(nil), value...)
+
+	return nil
+}
+
+// List returns the keys of a namespace in order
+func (m *MemoryStore) List(ctx context.Context, namespace string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys := make([]string, 0, len(m.namespaces[namespace]))
+	for key := range m.namespaces[namespace] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+// Delete removes a key
+func (m *MemoryStore) Delete(ctx context.Context, namespace, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.namespaces[namespace], key)
+
+	return nil
+}
+
+// FileStore is a Store which keeps each value in a file of a directory per namespace. Values are replaced atomically, so a crash never leaves a partly written value behind.
+type FileStore struct {
+	// Dir is the directory holding the namespaces
+	Dir string
+}
+
+// NewFileStore returns a FileStore in dir, which is created if it doesn't exist
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("could not create store directory: %w", err)
+	}
+
+	return &FileStore{Dir: dir}, nil
+}
+
+// path returns the file of a key. Names are escaped so they can't leave the directory.
+func (f *FileStore) path(namespace, key string) string {
+	return filepath.Join(f.Dir, url.PathEscape(namespace), url.PathEscape(key))
+}
+
+// Get returns the value of a key
+func (f *FileStore) Get(ctx context.Context, namespace, key string) ([]byte, error) {
+	b, err := os.ReadFile(f.path(namespace, key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, keyNotFound(namespace, key)
+	}
+
+	return b, err
+}
+
+// Put sets the value of a key
+func (f *FileStore) Put(ctx context.Context, namespace, key string, value []byte) error {
+	filename := f.path(namespace, key)
+	if err := os.MkdirAll(filepath.Dir(filename), 0o700); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(filename, value)
+}
+
+// List returns the keys of a namespace in order
+func (f *FileStore) List(ctx context.Context, namespace string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(f.Dir, url.PathEscape(namespace)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, e := range entries {
+		// Skip the temporary files of writes in progress
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+
+		key, err := url.PathUnescape(e.Name())
+		if err != nil {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+// Delete removes a key
+func (f *FileStore) Delete(ctx context.Context, namespace, key string) error {
+	err := os.Remove(f.path(namespace, key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// writeFileAtomic writes a file via a temporary file in the same directory, which is renamed over it
+func writeFileAtomic(filename string, b []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filename)
+}
@@ -0,0 +1,59 @@
+package godo
+
+import (
+	"context"
+	"strings"
+)
+
+// Tracer starts a span for each API call. It is meant to be implemented by a small adapter around e.g. an OpenTelemetry tracer, so API latency shows up in existing distributed traces.
+type Tracer interface {
+	// Start starts a span as a child of any span in ctx and returns a context carrying it. The returned context is used for the request.
+	Start(ctx context.Context, name string, attrs map[string]string) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer
+type Span interface {
+	// End ends the span with the HTTP status of the response, which is 0 if no response was received, and the error returned by the call
+	End(status int, err error)
+}
+
+// WithTracer starts a span with t for every API call
+func WithTracer(t Tracer) Option {
+	return func(c *Client) {
+		c.Tracer = t
+	}
+}
+
+// resourceAttributes maps the collection of an endpoint to the span attribute holding the ID that follows it
+var resourceAttributes = map[string]string{
+	"droplets": "godo.droplet_id",
+	"domains":  "godo.domain_id",
+	"records":  "godo.record_id",
+	"images":   "godo.image_id",
+	"events":   "godo.event_id",
+}
+
+func spanAttributes(method, endpoint string) map[string]string {
+	attrs := map[string]string{
+		"http.method":   method,
+		"godo.endpoint": endpointFamily(endpoint),
+	}
+
+	segments := strings.Split(strings.Trim(endpoint, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if key, ok := resourceAttributes[segments[i]]; ok && isIDSegment(segments[i+1]) {
+			attrs[key] = segments[i+1]
+		}
+	}
+
+	return attrs
+}
+
+// startSpan starts a span for the call when the client has a Tracer
+func (c *Client) startSpan(ctx context.Context, method, endpoint string) (context.Context, Span) {
+	if c.Tracer == nil {
+		return ctx, nil
+	}
+
+	return c.Tracer.Start(ctx, "godo "+endpointFamily(endpoint), spanAttributes(method, endpoint))
+}
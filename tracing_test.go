@@ -0,0 +1,41 @@
+package godo
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+type recordingTracer struct {
+	errs []string
+}
+
+type recordingSpan struct {
+	t *recordingTracer
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, attrs map[string]string) (context.Context, Span) {
+	return ctx, recordingSpan{t}
+}
+
+func (s recordingSpan) End(status int, err error) {
+	if err != nil {
+		s.t.errs = append(s.t.errs, err.Error())
+	}
+}
+
+func TestSpanErrorsDontLeakCredentials(t *testing.T) {
+	tracer := &recordingTracer{}
+	c := newFailingClient(WithTracer(tracer))
+
+	if _, err := c.GetDropletByID(123); err == nil {
+		t.Fatal("request through a failing transport succeeded")
+	}
+
+	if len(tracer.errs) != 1 {
+		t.Fatalf("spans ended with %d errors, want 1", len(tracer.errs))
+	}
+	if strings.Contains(tracer.errs[0], "SECRETKEY") {
+		t.Errorf("span error contains the API key: %s", tracer.errs[0])
+	}
+}
@@ -0,0 +1,83 @@
+package godo
+
+import (
+	"io"
+	"sync"
+)
+
+// TrafficObserver is implemented by a MetricsCollector which also wants the size of API calls, e.g. to export them as byte counters
+type TrafficObserver interface {
+	// ObserveTraffic is called after each API call with the endpoint as in ObserveRequest, see Stats for what the sizes include
+	ObserveTraffic(endpoint string, sent, received int)
+}
+
+// TrafficStats is the traffic of API calls
+type TrafficStats struct {
+	Requests      int64
+	BytesSent     int64
+	BytesReceived int64
+}
+
+// Stats is the traffic of the API calls of a client since it was created. BytesSent counts the request URIs, which carry the parameters, and bodies of every attempt; BytesReceived counts the response bodies as transferred, i.e. before decompression. Responses served from the cache or planned in dry-run mode are not counted.
+type Stats struct {
+	TrafficStats
+	// Endpoints is the traffic by endpoint family, e.g. "/droplets/{id}"
+	Endpoints map[string]TrafficStats
+}
+
+// trafficCounter sums the traffic of the API calls by endpoint family
+type trafficCounter struct {
+	mu        sync.Mutex
+	endpoints map[string]TrafficStats
+}
+
+func newTrafficCounter() *trafficCounter {
+	return &trafficCounter{endpoints: make(map[string]TrafficStats)}
+}
+
+func (t *trafficCounter) add(family string, sent, received int) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s := t.endpoints[family]
+	s.Requests++
+	s.BytesSent += int64(sent)
+	s.BytesReceived += int64(received)
+	t.endpoints[family] = s
+}
+
+// Stats returns the traffic of the API calls of the client, shared with the copies made by WithContext
+func (c *Client) Stats() Stats {
+	stats := Stats{Endpoints: make(map[string]TrafficStats)}
+	if c.traffic == nil {
+		return stats
+	}
+
+	c.traffic.mu.Lock()
+	defer c.traffic.mu.Unlock()
+
+	for family, s := range c.traffic.endpoints {
+		stats.Endpoints[family] = s
+		stats.Requests += s.Requests
+		stats.BytesSent += s.BytesSent
+		stats.BytesReceived += s.BytesReceived
+	}
+
+	return stats
+}
+
+// countingBody counts the bytes read from the body of a response
+type countingBody struct {
+	io.ReadCloser
+	n int
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += n
+	return n, err
+}
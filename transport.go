@@ -0,0 +1,85 @@
+package godo
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+)
+
+// configureTransport applies fn to a copy of the transport of the client's HTTP client, so shared clients and transports are never modified. Nothing is changed if the HTTP client uses a RoundTripper which isn't an *http.Transport.
+func (c *Client) configureTransport(fn func(t *http.Transport)) {
+	hc := http.Client{}
+	if c.HTTPClient != nil {
+		hc = *c.HTTPClient
+	}
+
+	var t *http.Transport
+	switch rt := hc.Transport.(type) {
+	case nil:
+		t = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		t = rt.Clone()
+	default:
+		return
+	}
+
+	fn(t)
+	hc.Transport = t
+	c.HTTPClient = &hc
+}
+
+// configureTLS applies fn to the TLS configuration of the client's transport
+func (c *Client) configureTLS(fn func(cfg *tls.Config)) {
+	c.configureTransport(func(t *http.Transport) {
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{}
+		}
+		fn(t.TLSClientConfig)
+	})
+}
+
+// WithTLSConfig sets the TLS configuration used to connect to the API
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(c *Client) {
+		c.configureTransport(func(t *http.Transport) {
+			t.TLSClientConfig = cfg.Clone()
+		})
+	}
+}
+
+// WithMinTLSVersion sets the minimum TLS version accepted, e.g. tls.VersionTLS12
+func WithMinTLSVersion(version uint16) Option {
+	return func(c *Client) {
+		c.configureTLS(func(cfg *tls.Config) {
+			cfg.MinVersion = version
+		})
+	}
+}
+
+// WithRootCAs sets the root certificate authorities used to verify the server, e.g. those of a TLS-intercepting corporate proxy
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(c *Client) {
+		c.configureTLS(func(cfg *tls.Config) {
+			cfg.RootCAs = pool
+		})
+	}
+}
+
+// WithClientCertificate adds a certificate presented to the server or proxy for client authentication
+func WithClientCertificate(cert tls.Certificate) Option {
+	return func(c *Client) {
+		c.configureTLS(func(cfg *tls.Config) {
+			cfg.Certificates = append(cfg.Certificates, cert)
+		})
+	}
+}
+
+// WithProxy routes all requests through the proxy at proxyURL instead of the one from the environment. The http, https and socks5 schemes are supported.
+func WithProxy(proxyURL *url.URL) Option {
+	return func(c *Client) {
+		c.configureTransport(func(t *http.Transport) {
+			t.Proxy = http.ProxyURL(proxyURL)
+		})
+	}
+}
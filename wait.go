@@ -0,0 +1,275 @@
+package godo
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultPollInterval is how often events and droplets are polled while waiting for them, unless Client.PollInterval is set
+	DefaultPollInterval = 5 * time.Second
+
+	// EventStatusDone is the action status of an event which has finished
+	EventStatusDone = "done"
+	// EventStatusErrored is the action status of an event which has failed
+	EventStatusErrored = "errored"
+
+	// stalledPollFactor caps the poll interval of an event which makes no progress, as a multiple of the client's poll interval
+	stalledPollFactor = 6
+)
+
+func (c *Client) pollInterval() time.Duration {
+	if c.PollInterval > 0 {
+		return c.PollInterval
+	}
+
+	return DefaultPollInterval
+}
+
+// sleep waits for the poll interval or until ctx is done
+func (c *Client) sleep(ctx context.Context) error {
+	return sleepContext(ctx, c.pollInterval())
+}
+
+// operationContext bounds ctx by the client's operation timeout
+func (c *Client) operationContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.OperationTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, c.OperationTimeout)
+}
+
+// EventError is returned when an event waited for has failed. It matches ErrEventFailed with errors.Is.
+type EventError struct {
+	EventID int
+	// Action is the action which started the event, if known, e.g. "reboot"
+	Action string
+	Event  *Event
+}
+
+func (e *EventError) Error() string {
+	if e.Action != "" {
+		return fmt.Sprintf("%s of droplet with ID %d failed with event %d", e.Action, e.Event.DropletID, e.EventID)
+	}
+
+	return fmt.Sprintf("event %d of droplet with ID %d failed", e.EventID, e.Event.DropletID)
+}
+
+// Is reports whether target is ErrEventFailed
+func (e *EventError) Is(target error) bool {
+	return target == ErrEventFailed
+}
+
+// WaitForEvent polls an event until it is done or ctx is done. An event which fails is returned as an EventError. The wait is bounded by the client's operation timeout. While the percentage of the event doesn't change, the poll interval is doubled up to stalledPollFactor times the client's poll interval. With WithSharedEventPolling, waiters for the same event share one poll.
+func (c *Client) WaitForEvent(ctx context.Context, eventID int) (*Event, error) {
+	if c.DryRun() && eventID == 0 {
+		// The event of a planned call
+		return &Event{ActionStatus: EventStatusDone}, nil
+	}
+
+	ctx, cancel := c.operationContext(ctx)
+	defer cancel()
+
+	if c.events != nil {
+		return c.events.wait(ctx, c, eventID)
+	}
+
+	return c.pollEvent(ctx, eventID)
+}
+
+// pollEvent polls an event until it is done, backing off while it makes no progress
+func (c *Client) pollEvent(ctx context.Context, eventID int) (*Event, error) {
+	interval := c.pollInterval()
+	percentage := -1.0
+
+	for {
+		e, err := c.WithContext(ctx).GetEventByID(eventID)
+		if err == nil && e.ActionStatus == EventStatusErrored {
+			err = &EventError{EventID: eventID, Event: e}
+		}
+		c.reportEvent(eventID, e, err)
+		if err != nil {
+			return nil, err
+		}
+
+		if e.ActionStatus == EventStatusDone {
+			return e, nil
+		}
+
+		if e.Percentage == percentage {
+			interval = min(interval*2, stalledPollFactor*c.pollInterval())
+		} else {
+			interval = c.pollInterval()
+		}
+		percentage = e.Percentage
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// eventPoll is a poll of an event shared by its waiters
+type eventPoll struct {
+	done    chan struct{}
+	event   *Event
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// eventPoller shares the polls of events between the goroutines waiting for them
+type eventPoller struct {
+	mu    sync.Mutex
+	polls map[int]*eventPoll
+}
+
+// WithSharedEventPolling makes goroutines which wait for the same event with WaitForEvent share one poll of it, which saves API calls when many of them await one event. The poll stops once all waiters have given up.
+func WithSharedEventPolling() Option {
+	return func(c *Client) {
+		c.events = &eventPoller{polls: make(map[int]*eventPoll)}
+	}
+}
+
+func (p *eventPoller) wait(ctx context.Context, c *Client, eventID int) (*Event, error) {
+	p.mu.Lock()
+	poll, ok := p.polls[eventID]
+	if !ok {
+		// The poll outlives the waiter which starts it
+		pollCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		poll = &eventPoll{done: make(chan struct{}), cancel: cancel}
+		p.polls[eventID] = poll
+
+		go func() {
+			defer cancel()
+
+			poll.event, poll.err = c.pollEvent(pollCtx, eventID)
+
+			p.mu.Lock()
+			if p.polls[eventID] == poll {
+				delete(p.polls, eventID)
+			}
+			p.mu.Unlock()
+
+			close(poll.done)
+		}()
+	}
+	poll.waiters++
+	p.mu.Unlock()
+
+	select {
+	case <-poll.done:
+		return poll.event, poll.err
+	case <-ctx.Done():
+		p.mu.Lock()
+		poll.waiters--
+		if poll.waiters == 0 {
+			poll.cancel()
+			if p.polls[eventID] == poll {
+				delete(p.polls, eventID)
+			}
+		}
+		p.mu.Unlock()
+
+		return nil, ctx.Err()
+	}
+}
+
+// WaitForDropletStatus polls a droplet until it has the given status and is no longer locked, or until ctx is done. The wait is bounded by the client's operation timeout.
+func (c *Client) WaitForDropletStatus(ctx context.Context, ID int, status DropletStatus) (*Droplet, error) {
+	if c.DryRun() && ID == 0 {
+		// A droplet whose creation was planned
+		return &Droplet{Status: status}, nil
+	}
+
+	ctx, cancel := c.operationContext(ctx)
+	defer cancel()
+
+	for {
+		d, err := c.WithContext(ctx).GetDropletByID(ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if d.Is(status) {
+			if status.IsActive() {
+				c.bootReached(ID, BootActive)
+			}
+
+			return d, nil
+		}
+
+		if err := c.sleep(ctx); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// DeletionStalledError is returned by DeleteDropletAndWait when ctx is done before the droplet is gone
+type DeletionStalledError struct {
+	ID      int
+	EventID int
+	// Droplet is the droplet as last listed, or nil if the destroy event didn't finish
+	Droplet *Droplet
+	Err     error
+}
+
+func (e *DeletionStalledError) Error() string {
+	if e.Droplet == nil {
+		return fmt.Sprintf("destroy event %d of droplet with ID %d didn't finish: %v", e.EventID, e.ID, e.Err)
+	}
+
+	return fmt.Sprintf("droplet with ID %d is still listed with status %q after it was destroyed: %v", e.ID, e.Droplet.Status, e.Err)
+}
+
+func (e *DeletionStalledError) Unwrap() error {
+	return e.Err
+}
+
+// DeleteDropletAndWait deletes a droplet, waits for the destroy event to finish and then polls the droplet list until the droplet no longer appears in it. If ctx is done first, a DeletionStalledError is returned. The wait is bounded by the client's operation timeout.
+func (c *Client) DeleteDropletAndWait(ctx context.Context, ID int) error {
+	ctx, cancel := c.operationContext(ctx)
+	defer cancel()
+
+	eventID, err := c.WithContext(ctx).DeleteDropletByID(ID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.WaitForEvent(ctx, eventID); err != nil {
+		if ctx.Err() != nil {
+			return &DeletionStalledError{ID: ID, EventID: eventID, Err: err}
+		}
+
+		return err
+	}
+
+	if c.DryRun() {
+		// The droplet stays listed since the deletion was only planned
+		return nil
+	}
+
+	listed := &Droplet{ID: ID}
+	for {
+		droplets, err := c.WithContext(ctx).GetAllDroplets()
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+
+		if err == nil {
+			i := slices.IndexFunc(droplets, func(d Droplet) bool { return d.ID == ID })
+			if i < 0 {
+				return nil
+			}
+			listed = &droplets[i]
+		}
+
+		if err := c.sleep(ctx); err != nil {
+			return &DeletionStalledError{ID: ID, EventID: eventID, Droplet: listed, Err: err}
+		}
+	}
+}
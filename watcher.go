@@ -0,0 +1,271 @@
+package godo
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DropletEventType is the kind of change a DropletWatcher observed
+type DropletEventType string
+
+const (
+	// DropletAdded is sent when a droplet appears
+	DropletAdded DropletEventType = "added"
+	// DropletUpdated is sent when any field of a droplet changes
+	DropletUpdated DropletEventType = "updated"
+	// DropletRemoved is sent when a droplet disappears
+	DropletRemoved DropletEventType = "removed"
+)
+
+// DropletEvent is a change to a droplet observed by a DropletWatcher
+type DropletEvent struct {
+	Type    DropletEventType
+	Droplet Droplet
+	// Previous is the droplet before an update
+	Previous Droplet
+}
+
+// DropletWatcher polls the droplets of an account and keeps an up-to-date view of them
+type DropletWatcher struct {
+	client   *Client
+	interval time.Duration
+
+	mu       sync.RWMutex
+	droplets map[int]Droplet
+	subs     []*Subscription
+	stopped  bool
+	err      error
+}
+
+// NewDropletWatcher returns a watcher which polls the droplets every interval once it is run
+func NewDropletWatcher(c *Client, interval time.Duration) *DropletWatcher {
+	return &DropletWatcher{
+		client:   c,
+		interval: interval,
+		droplets: make(map[int]Droplet),
+	}
+}
+
+// Run polls the droplets until ctx is done. Failed polls are retried at the next interval, the error is available from Err meanwhile. The subscriber channels are closed when Run returns, and so are those of later subscriptions.
+func (w *DropletWatcher) Run(ctx context.Context) error {
+	defer w.closeSubscribers()
+
+	t := time.NewTicker(w.interval)
+	defer t.Stop()
+
+	for {
+		w.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+func (w *DropletWatcher) poll(ctx context.Context) {
+	droplets, err := w.client.WithContext(ctx).GetAllDroplets()
+
+	w.mu.Lock()
+	w.err = err
+	if err != nil {
+		w.mu.Unlock()
+		return
+	}
+
+	var events []DropletEvent
+	current := make(map[int]Droplet, len(droplets))
+	for _, d := range droplets {
+		current[d.ID] = d
+
+		old, ok := w.droplets[d.ID]
+		switch {
+		case !ok:
+			events = append(events, DropletEvent{Type: DropletAdded, Droplet: d})
+		case !old.Equal(d):
+			events = append(events, DropletEvent{Type: DropletUpdated, Droplet: d, Previous: old})
+		}
+	}
+	for id, d := range w.droplets {
+		if _, ok := current[id]; !ok {
+			events = append(events, DropletEvent{Type: DropletRemoved, Droplet: d})
+		}
+	}
+	w.droplets = current
+	subs := w.subs
+	w.mu.Unlock()
+
+	for _, e := range events {
+		// Subscribers which drop events never wait, so they are served before the blocking ones
+		for _, sub := range subs {
+			if sub.policy != Block {
+				sub.deliver(ctx, e)
+			}
+		}
+		for _, sub := range subs {
+			if sub.policy == Block {
+				sub.deliver(ctx, e)
+			}
+		}
+	}
+}
+
+// OverflowPolicy decides what happens to an event when a subscriber's buffer is full
+type OverflowPolicy int
+
+const (
+	// Block waits until the subscriber has room. This holds up the watcher, so later events reach every subscriber late.
+	Block OverflowPolicy = iota
+	// DropNewest discards the event which didn't fit
+	DropNewest
+	// DropOldest discards the oldest buffered event to make room
+	DropOldest
+)
+
+// Subscription receives the changes observed by a DropletWatcher on C until it is closed
+type Subscription struct {
+	C <-chan DropletEvent
+
+	w       *DropletWatcher
+	ch      chan DropletEvent
+	policy  OverflowPolicy
+	dropped atomic.Uint64
+
+	// mu guards sending on ch against closing it, done unblocks a send waiting for room
+	mu       sync.Mutex
+	closed   bool
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+// Dropped returns how many events were discarded because the subscriber fell behind
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Close stops the subscription and closes C. Subscribers which stop reading before the watcher stops must close their subscription, as a blocking one would hold up the watcher otherwise.
+func (s *Subscription) Close() {
+	if s.w != nil {
+		s.w.unsubscribe(s)
+	}
+	s.close()
+}
+
+func (s *Subscription) close() {
+	s.doneOnce.Do(func() { close(s.done) })
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed {
+		s.closed = true
+		close(s.ch)
+	}
+}
+
+func (s *Subscription) deliver(ctx context.Context, e DropletEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	switch s.policy {
+	case DropNewest:
+		select {
+		case s.ch <- e:
+		default:
+			s.dropped.Add(1)
+		}
+	case DropOldest:
+		for {
+			select {
+			case s.ch <- e:
+				return
+			default:
+			}
+
+			select {
+			case <-s.ch:
+				s.dropped.Add(1)
+			default:
+			}
+		}
+	default:
+		select {
+		case s.ch <- e:
+		case <-ctx.Done():
+		case <-s.done:
+		}
+	}
+}
+
+// Subscribe returns a subscription receiving every change the watcher observes. Up to buffer events are queued for the subscriber, policy decides what happens when it falls further behind. The subscription must be closed when the subscriber stops reading, see Subscription.Close. If the watcher has stopped already, C is closed right away.
+func (w *DropletWatcher) Subscribe(buffer int, policy OverflowPolicy) *Subscription {
+	if policy != Block && buffer < 1 {
+		buffer = 1
+	}
+
+	ch := make(chan DropletEvent, buffer)
+	sub := &Subscription{C: ch, w: w, ch: ch, policy: policy, done: make(chan struct{})}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.stopped {
+		sub.close()
+		return sub
+	}
+	w.subs = append(w.subs, sub)
+
+	return sub
+}
+
+// unsubscribe removes a subscription from the watcher
+func (w *DropletWatcher) unsubscribe(sub *Subscription) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.subs = slices.DeleteFunc(slices.Clone(w.subs), func(s *Subscription) bool { return s == sub })
+}
+
+func (w *DropletWatcher) closeSubscribers() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, sub := range w.subs {
+		sub.close()
+	}
+	w.subs = nil
+	w.stopped = true
+}
+
+// Droplets returns the droplets seen in the last successful poll, ordered by ID
+func (w *DropletWatcher) Droplets() []Droplet {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	droplets := make([]Droplet, 0, len(w.droplets))
+	for _, d := range w.droplets {
+		droplets = append(droplets, d)
+	}
+	sort.Slice(droplets, func(i, j int) bool {
+		return droplets[i].ID < droplets[j].ID
+	})
+
+	return droplets
+}
+
+// Err returns the error of the last poll, or nil if it succeeded
+func (w *DropletWatcher) Err() error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.err
+}
@@ -0,0 +1,288 @@
+package godo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync/atomic"
+)
+
+// workflowRuns counts the runs started with Run, so each reports its progress under its own key
+var workflowRuns atomic.Int64
+
+// errWorkflowFailedEarlier is the error of a stored run resumed only to retry its failed compensations
+var errWorkflowFailedEarlier = errors.New("run failed earlier, retrying failed compensations")
+
+// WorkflowState is the state of a workflow run. It records the completed steps, so an interrupted run can be resumed, and holds the values steps pass on to each other.
+type WorkflowState struct {
+	// Completed holds the names of the completed steps in order
+	Completed []string `json:"completed"`
+	// Values holds data passed between steps, e.g. the IDs of created resources
+	Values map[string]string `json:"values"`
+	// Failed is the name of the step a run failed at, set while steps are left to compensate
+	Failed string `json:"failed,omitempty"`
+}
+
+// Set stores a value for later steps
+func (st *WorkflowState) Set(key, value string) {
+	if st.Values == nil {
+		st.Values = make(map[string]string)
+	}
+	st.Values[key] = value
+}
+
+// Get returns a value stored by an earlier step
+func (st *WorkflowState) Get(key string) string {
+	return st.Values[key]
+}
+
+// SetInt stores an integer value, e.g. an ID, for later steps
+func (st *WorkflowState) SetInt(key string, value int) {
+	st.Set(key, strconv.Itoa(value))
+}
+
+// Int returns an integer value stored by an earlier step, or 0 if there is none
+func (st *WorkflowState) Int(key string) int {
+	i, _ := strconv.Atoi(st.Get(key))
+	return i
+}
+
+// clone returns a copy of the state which can be changed independently. A nil state clones to an empty one.
+func (st *WorkflowState) clone() *WorkflowState {
+	cp := &WorkflowState{}
+	if st == nil {
+		return cp
+	}
+
+	cp.Completed = append(cp.Completed, st.Completed...)
+	cp.Failed = st.Failed
+	for k, v := range st.Values {
+		cp.Set(k, v)
+	}
+
+	return cp
+}
+
+func (st *WorkflowState) completed(step string) bool {
+	for _, s := range st.Completed {
+		if s == step {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WorkflowStep is a step of a Workflow
+type WorkflowStep struct {
+	Name string
+
+	// Run performs the step. If it returns an event ID other than 0, the workflow waits for the event to finish before continuing.
+	Run func(ctx context.Context, c *Client, st *WorkflowState) (eventID int, err error)
+
+	// Compensate undoes the step when a later step fails. It is optional.
+	Compensate func(ctx context.Context, c *Client, st *WorkflowState) error
+}
+
+// Workflow is a saga of steps over the API, e.g. snapshot a droplet, wait and create a copy of it. When a step fails, the completed steps are compensated in reverse order.
+type Workflow struct {
+	Name  string
+	Steps []WorkflowStep
+}
+
+// NewWorkflow returns a workflow running steps in order
+func NewWorkflow(name string, steps ...WorkflowStep) *Workflow {
+	return &Workflow{Name: name, Steps: steps}
+}
+
+// WorkflowError is returned when a step of a workflow fails
+type WorkflowError struct {
+	Workflow string
+	Step     string
+	Err      error
+	// CompensationErrs holds the errors of compensations which failed in turn
+	CompensationErrs []error
+}
+
+func (e *WorkflowError) Error() string {
+	msg := fmt.Sprintf("workflow %s failed at step %s: %v", e.Workflow, e.Step, e.Err)
+	if len(e.CompensationErrs) > 0 {
+		msg += fmt.Sprintf(" (%d compensations failed)", len(e.CompensationErrs))
+	}
+
+	return msg
+}
+
+func (e *WorkflowError) Unwrap() error {
+	return e.Err
+}
+
+// Run runs the steps in order with c. Steps recorded as completed in st are skipped, so a run can be resumed by passing the state of an interrupted one. A nil st starts from scratch. If a step fails, st is left holding the completed steps whose compensation failed.
+func (w *Workflow) Run(ctx context.Context, c *Client, st *WorkflowState) error {
+	if st == nil {
+		st = &WorkflowState{}
+	}
+
+	key := fmt.Sprintf("workflow %s #%d", w.Name, workflowRuns.Add(1))
+	if step, err := w.run(ctx, c, st, key, nil); err != nil {
+		return &WorkflowError{
+			Workflow:         w.Name,
+			Step:             step,
+			Err:              err,
+			CompensationErrs: w.compensate(ctx, c, st),
+		}
+	}
+
+	return nil
+}
+
+// RunStored runs the workflow like Run, saving the state in s after each step under the run's ID, so a run interrupted by a crash is resumed by calling RunStored again with the same ID. The state is removed once the run has finished or been compensated. If a compensation fails, the state is kept and calling RunStored again with the same ID retries the failed compensations instead of running the steps.
+func (w *Workflow) RunStored(ctx context.Context, c *Client, s Store, runID string) error {
+	namespace := "workflow/" + w.Name
+
+	st := &WorkflowState{}
+	b, err := s.Get(ctx, namespace, runID)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(b, st); err != nil {
+			return fmt.Errorf("could not load state of workflow %s run %s: %w", w.Name, runID, err)
+		}
+	case !errors.Is(err, ErrNotFound):
+		return fmt.Errorf("could not load state of workflow %s run %s: %w", w.Name, runID, err)
+	}
+
+	save := func(ctx context.Context, st *WorkflowState) error {
+		b, err := json.Marshal(st)
+		if err != nil {
+			return err
+		}
+
+		return s.Put(ctx, namespace, runID, b)
+	}
+
+	// fail compensates a failed run, keeping its state while compensations are left
+	fail := func(step string, err error) error {
+		wfErr := &WorkflowError{
+			Workflow:         w.Name,
+			Step:             step,
+			Err:              err,
+			CompensationErrs: w.compensate(ctx, c, st),
+		}
+
+		ctx := context.WithoutCancel(ctx)
+		if len(wfErr.CompensationErrs) > 0 {
+			st.Failed = step
+			if err := save(ctx, st); err != nil {
+				wfErr.CompensationErrs = append(wfErr.CompensationErrs, fmt.Errorf("could not save workflow state: %w", err))
+			}
+
+			return wfErr
+		}
+
+		s.Delete(ctx, namespace, runID)
+
+		return wfErr
+	}
+
+	if st.Failed != "" {
+		return fail(st.Failed, errWorkflowFailedEarlier)
+	}
+
+	key := fmt.Sprintf("workflow %s run %s", w.Name, runID)
+	if step, err := w.run(ctx, c, st, key, func(st *WorkflowState) error { return save(ctx, st) }); err != nil {
+		return fail(step, err)
+	}
+
+	return s.Delete(ctx, namespace, runID)
+}
+
+// Explain returns the mutating calls a run with c would make, in order, without making them, see Client.Explain. st is left unchanged. If a step fails, the plan up to it is returned with a WorkflowError; nothing is compensated.
+func (w *Workflow) Explain(ctx context.Context, c *Client, st *WorkflowState) ([]PlannedCall, error) {
+	dry := c.dryRunCopy()
+
+	if step, err := w.run(ctx, dry, st.clone(), "workflow "+w.Name, nil); err != nil {
+		return dry.Plan(), &WorkflowError{Workflow: w.Name, Step: step, Err: err}
+	}
+
+	return dry.Plan(), nil
+}
+
+// run runs the steps which aren't completed yet and returns the name of the step which failed. Progress is reported under key. save is called with the state after each step if it is not nil.
+func (w *Workflow) run(ctx context.Context, c *Client, st *WorkflowState, key string, save func(*WorkflowState) error) (string, error) {
+	for _, step := range w.Steps {
+		if st.completed(step.Name) {
+			continue
+		}
+
+		w.report(c, st, key, step.Name, nil)
+
+		eventID, err := step.Run(ctx, c, st)
+		if err == nil && eventID != 0 {
+			_, err = c.WaitForEvent(ctx, eventID)
+		}
+
+		if err != nil {
+			w.report(c, st, key, step.Name, err)
+			return step.Name, err
+		}
+
+		st.Completed = append(st.Completed, step.Name)
+		w.report(c, st, key, step.Name, nil)
+
+		if save != nil {
+			if err := save(st); err != nil {
+				return step.Name, fmt.Errorf("could not save workflow state: %w", err)
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// report reports the progress of the run identified by key to the client's Progress, measured in completed steps
+func (w *Workflow) report(c *Client, st *WorkflowState, key, step string, err error) {
+	if c.progress == nil {
+		return
+	}
+
+	op := OperationProgress{Key: key, Detail: step, Err: err, Done: err != nil}
+	if len(w.Steps) > 0 {
+		op.Percentage = float64(len(st.Completed)) * 100 / float64(len(w.Steps))
+	}
+	if len(st.Completed) == len(w.Steps) {
+		op.Done = true
+	}
+
+	c.progress.Report(op)
+}
+
+// compensate undoes the completed steps in reverse order. It keeps going when ctx is canceled, since a half-done workflow is worse than a slow cleanup. Only the steps whose compensation failed are left completed in st.
+func (w *Workflow) compensate(ctx context.Context, c *Client, st *WorkflowState) []error {
+	ctx = context.WithoutCancel(ctx)
+
+	steps := make(map[string]WorkflowStep, len(w.Steps))
+	for _, s := range w.Steps {
+		steps[s.Name] = s
+	}
+
+	var errs []error
+	var failed []string
+	for i := len(st.Completed) - 1; i >= 0; i-- {
+		step := steps[st.Completed[i]]
+		if step.Compensate == nil {
+			continue
+		}
+
+		if err := step.Compensate(ctx, c, st); err != nil {
+			errs = append(errs, fmt.Errorf("could not compensate step %s: %w", step.Name, err))
+			failed = append(failed, step.Name)
+		}
+	}
+	slices.Reverse(failed)
+	st.Completed = failed
+
+	return errs
+}
@@ -0,0 +1,63 @@
+package godo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestRunStoredKeepsStateWhenCompensationFails(t *testing.T) {
+	ctx := context.Background()
+	c := NewClient(WithCredentials("id", "key"))
+	s := NewMemoryStore()
+
+	compensations := 0
+	wf := NewWorkflow("test",
+		WorkflowStep{
+			Name: "create",
+			Run:  func(ctx context.Context, c *Client, st *WorkflowState) (int, error) { return 0, nil },
+			Compensate: func(ctx context.Context, c *Client, st *WorkflowState) error {
+				compensations++
+				if compensations == 1 {
+					return errors.New("API is down")
+				}
+				return nil
+			},
+		},
+		WorkflowStep{
+			Name: "fail",
+			Run: func(ctx context.Context, c *Client, st *WorkflowState) (int, error) {
+				return 0, errors.New("step failed")
+			},
+		},
+	)
+
+	var wfErr *WorkflowError
+	if err := wf.RunStored(ctx, c, s, "run-1"); !errors.As(err, &wfErr) || len(wfErr.CompensationErrs) != 1 {
+		t.Fatalf("got error %v, want a WorkflowError with a failed compensation", err)
+	}
+
+	b, err := s.Get(ctx, "workflow/test", "run-1")
+	if err != nil {
+		t.Fatalf("state was removed although a compensation failed: %v", err)
+	}
+	var st WorkflowState
+	if err := json.Unmarshal(b, &st); err != nil {
+		t.Fatal(err)
+	}
+	if st.Failed != "fail" || !slices.Equal(st.Completed, []string{"create"}) {
+		t.Errorf("stored state is %+v, want step create left to compensate after step fail", st)
+	}
+
+	if err := wf.RunStored(ctx, c, s, "run-1"); !errors.As(err, &wfErr) || len(wfErr.CompensationErrs) != 0 {
+		t.Fatalf("got error %v, want a WorkflowError without failed compensations", err)
+	}
+	if compensations != 2 {
+		t.Errorf("compensated %d times, want 2", compensations)
+	}
+	if _, err := s.Get(ctx, "workflow/test", "run-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("state wasn't removed once compensated: %v", err)
+	}
+}
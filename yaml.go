@@ -0,0 +1,382 @@
+package godo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// yamlNumber matches the plain scalars which are numbers
+var yamlNumber = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][-+]?[0-9]+)?$`)
+
+type yamlCodec struct{}
+
+func (yamlCodec) Encode(w io.Writer, v interface{}) error {
+	tree, err := toTree(v)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	switch t := tree.(type) {
+	case object:
+		writeYAMLObject(&buf, t, 0, false)
+	case []interface{}:
+		writeYAMLList(&buf, t, 0)
+	default:
+		buf.WriteString(yamlScalar(t) + "\n")
+	}
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// writeYAMLValue writes a value which follows a key or a sequence entry, including the line break
+func writeYAMLValue(buf *bytes.Buffer, v interface{}, indent int) {
+	switch t := v.(type) {
+	case object:
+		if len(t) == 0 {
+			buf.WriteString(" {}\n")
+			return
+		}
+
+		buf.WriteString("\n")
+		writeYAMLObject(buf, t, indent, false)
+	case []interface{}:
+		if len(t) == 0 {
+			buf.WriteString(" []\n")
+			return
+		}
+
+		buf.WriteString("\n")
+		writeYAMLList(buf, t, indent)
+	default:
+		buf.WriteString(" " + yamlScalar(t) + "\n")
+	}
+}
+
+// writeYAMLObject writes a block mapping. If inline is set, the first key follows a sequence entry on the same line.
+func writeYAMLObject(buf *bytes.Buffer, obj object, indent int, inline bool) {
+	for i, f := range obj {
+		if i > 0 || !inline {
+			buf.WriteString(strings.Repeat(" ", indent))
+		}
+
+		buf.WriteString(yamlScalar(f.key) + ":")
+		writeYAMLValue(buf, f.value, indent+2)
+	}
+}
+
+func writeYAMLList(buf *bytes.Buffer, list []interface{}, indent int) {
+	for _, item := range list {
+		buf.WriteString(strings.Repeat(" ", indent) + "-")
+
+		if obj, ok := item.(object); ok && len(obj) > 0 {
+			buf.WriteString(" ")
+			writeYAMLObject(buf, obj, indent+2, true)
+			continue
+		}
+
+		writeYAMLValue(buf, item, indent+2)
+	}
+}
+
+// yamlScalar formats a scalar, quoting strings which would otherwise be read as something else
+func yamlScalar(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(t)
+	case json.Number:
+		return t.String()
+	case string:
+		if yamlPlainSafe(t) {
+			return t
+		}
+
+		return strconv.Quote(t)
+	}
+
+	return fmt.Sprint(v)
+}
+
+func yamlPlainSafe(s string) bool {
+	if s == "" || strings.TrimSpace(s) != s || strings.ContainsAny(s, "\n\t") {
+		return false
+	}
+
+	if strings.ContainsRune("-?:,[]{}#&*!|>'\"%@`~", rune(s[0])) {
+		return false
+	}
+
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return false
+	}
+
+	_, isString := parseYAMLScalar(s).(string)
+	return isString
+}
+
+// parseYAMLScalar parses a plain scalar
+func parseYAMLScalar(s string) interface{} {
+	switch s {
+	case "null", "~":
+		return nil
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+
+	if yamlNumber.MatchString(s) {
+		return json.Number(s)
+	}
+
+	return s
+}
+
+type yamlLine struct {
+	num    int
+	indent int
+	text   string
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+func (yamlCodec) Decode(r io.Reader, v interface{}) error {
+	var p yamlParser
+
+	s := bufio.NewScanner(r)
+	for num := 1; s.Scan(); num++ {
+		line := s.Text()
+		if strings.HasPrefix(strings.TrimLeft(line, " "), "\t") {
+			return fmt.Errorf("line %d: tabs can't be used for indentation", num)
+		}
+
+		text := strings.TrimSpace(stripYAMLComment(line))
+		if text == "" || text == "---" {
+			continue
+		}
+
+		p.lines = append(p.lines, yamlLine{num: num, indent: len(line) - len(strings.TrimLeft(line, " ")), text: text})
+	}
+	if err := s.Err(); err != nil {
+		return err
+	}
+
+	var tree interface{} = object{}
+	if len(p.lines) > 0 {
+		var err error
+		if tree, err = p.parseBlock(p.lines[0].indent); err != nil {
+			return err
+		}
+
+		if p.pos < len(p.lines) {
+			return fmt.Errorf("line %d: unexpected indentation", p.lines[p.pos].num)
+		}
+	}
+
+	return fromTree(tree, v)
+}
+
+// stripYAMLComment removes a comment which starts outside of quotes
+func stripYAMLComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#' && (i == 0 || line[i-1] == ' '):
+			return line[:i]
+		}
+	}
+
+	return line
+}
+
+func isYAMLEntry(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// yamlKey splits a "key: value" line. It returns false if the line isn't a mapping entry.
+func yamlKey(text string) (string, string, bool) {
+	if text == "" || text[0] == '"' || text[0] == '\'' || text[0] == '[' || text[0] == '{' {
+		return "", "", false
+	}
+
+	if strings.HasSuffix(text, ":") && !strings.Contains(text, ": ") {
+		return strings.TrimSuffix(text, ":"), "", true
+	}
+
+	i := strings.Index(text, ": ")
+	if i < 0 {
+		return "", "", false
+	}
+
+	return text[:i], strings.TrimSpace(text[i+2:]), true
+}
+
+func (p *yamlParser) parseBlock(indent int) (interface{}, error) {
+	if isYAMLEntry(p.lines[p.pos].text) {
+		return p.parseList(indent)
+	}
+
+	return p.parseObject(indent)
+}
+
+func (p *yamlParser) parseObject(indent int) (interface{}, error) {
+	obj := object{}
+
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent && !isYAMLEntry(p.lines[p.pos].text) {
+		line := p.lines[p.pos]
+		key, rest, ok := yamlKey(line.text)
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected \"key: value\"", line.num)
+		}
+		p.pos++
+
+		value, err := p.parseValue(indent, rest, line.num, true)
+		if err != nil {
+			return nil, err
+		}
+
+		obj = append(obj, field{key, value})
+	}
+
+	return obj, nil
+}
+
+func (p *yamlParser) parseList(indent int) (interface{}, error) {
+	list := []interface{}{}
+
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent && isYAMLEntry(p.lines[p.pos].text) {
+		line := p.lines[p.pos]
+		rest := strings.TrimSpace(strings.TrimPrefix(line.text, "-"))
+
+		if _, _, ok := yamlKey(rest); ok {
+			// A mapping which starts on the line of the entry continues at the indentation of its first key
+			p.lines[p.pos] = yamlLine{num: line.num, indent: indent + len(line.text) - len(rest), text: rest}
+
+			value, err := p.parseObject(p.lines[p.pos].indent)
+			if err != nil {
+				return nil, err
+			}
+
+			list = append(list, value)
+			continue
+		}
+		p.pos++
+
+		value, err := p.parseValue(indent, rest, line.num, false)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, value)
+	}
+
+	return list, nil
+}
+
+// parseValue parses the value after a key or an entry: either the rest of its line or the block below it
+func (p *yamlParser) parseValue(indent int, rest string, num int, inObject bool) (interface{}, error) {
+	if rest != "" {
+		return parseYAMLFlow(rest, num)
+	}
+
+	if p.pos < len(p.lines) {
+		next := p.lines[p.pos]
+		if next.indent > indent {
+			return p.parseBlock(next.indent)
+		}
+
+		// A sequence may be at the indentation of its key
+		if inObject && next.indent == indent && isYAMLEntry(next.text) {
+			return p.parseList(indent)
+		}
+	}
+
+	return nil, nil
+}
+
+// parseYAMLFlow parses a scalar or a flow sequence of scalars
+func parseYAMLFlow(s string, num int) (interface{}, error) {
+	switch {
+	case s == "{}":
+		return object{}, nil
+	case strings.HasPrefix(s, "["):
+		if !strings.HasSuffix(s, "]") {
+			return nil, fmt.Errorf("line %d: unterminated sequence", num)
+		}
+
+		list := []interface{}{}
+		for _, item := range splitFlow(strings.TrimSpace(s[1 : len(s)-1])) {
+			v, err := parseYAMLFlow(item, num)
+			if err != nil {
+				return nil, err
+			}
+
+			list = append(list, v)
+		}
+
+		return list, nil
+	case strings.HasPrefix(s, "{"):
+		return nil, fmt.Errorf("line %d: flow mappings are not supported", num)
+	case strings.HasPrefix(s, `"`):
+		v, err := strconv.Unquote(s)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid string %s", num, s)
+		}
+
+		return v, nil
+	case strings.HasPrefix(s, "'"):
+		if len(s) < 2 || !strings.HasSuffix(s, "'") {
+			return nil, fmt.Errorf("line %d: invalid string %s", num, s)
+		}
+
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	}
+
+	return parseYAMLScalar(s), nil
+}
+
+// splitFlow splits the items of a flow sequence at the commas outside of quotes
+func splitFlow(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	var (
+		items []string
+		quote rune
+		start int
+	)
+	for i, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == ',':
+			items = append(items, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+
+	return append(items, strings.TrimSpace(s[start:]))
+}